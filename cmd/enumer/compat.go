@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	severityBreaking = "breaking"
+	severityWarning  = "warning"
+	severityInfo     = "info"
+)
+
+type compatArgsData struct {
+	InputPath string
+	OldPath   string
+	WriteLock bool
+}
+
+// compatLock is the part of a config which generated code and stored data
+// depend on, saved so later versions of the config can be checked against it.
+type compatLock struct {
+	Type     string             `yaml:"type"`
+	Features []string           `yaml:"features,omitempty"`
+	Values   []enumer.EnumValue `yaml:"values,omitempty"`
+}
+
+type compatChange struct {
+	Severity string
+	Message  string
+}
+
+func processCompat(arguments []string) error {
+	var args compatArgsData
+
+	flags := flag.NewFlagSet("compat", flag.ExitOnError)
+	flags.StringVar(&args.InputPath, "config", "", "The current version of the enum config.")
+	flags.StringVar(&args.OldPath, "old", "", "The previous version, a .enum.yaml config or a .enum.lock file, defaults to the config's lock file.")
+	flags.BoolVar(&args.WriteLock, "write-lock", false, "Write the config's lock file instead of checking it.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if args.InputPath == "" {
+		return errors.New("missing config path")
+	}
+
+	if absPath, err := filepath.Abs(args.InputPath); err != nil {
+		return err
+	} else {
+		args.InputPath = absPath
+	}
+
+	current, err := loadCompat(args.InputPath)
+
+	if err != nil {
+		return err
+	}
+
+	lockPath := compatLockPath(args.InputPath)
+
+	if args.WriteLock {
+		bs, err := yaml.Marshal(current)
+
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(lockPath, bs, FilePermissions)
+	}
+
+	if args.OldPath == "" {
+		args.OldPath = lockPath
+	}
+
+	old, err := loadCompat(args.OldPath)
+
+	if err != nil {
+		return err
+	}
+
+	changes := compareCompat(old, current)
+	breaking := 0
+
+	for _, change := range changes {
+		fmt.Printf("%v: %v\n", change.Severity, change.Message)
+
+		if change.Severity == severityBreaking {
+			breaking++
+		}
+	}
+
+	if breaking > 0 {
+		return fmt.Errorf("%v breaking change(s) to %v", breaking, current.Type)
+	}
+
+	return nil
+}
+
+// compatLockPath returns the lock file of a config, e.g. status.enum.yaml has
+// status.enum.lock.
+func compatLockPath(path string) string {
+	return stringer.TrimSuffix(path, ".yaml") + ".lock"
+}
+
+// loadCompat loads a lock file, or a config which is processed the same way
+// it is when generating.
+func loadCompat(path string) (compatLock, error) {
+	if strings.HasSuffix(path, ".lock") {
+		var lock compatLock
+
+		bs, err := os.ReadFile(path)
+
+		if err != nil {
+			return lock, fmt.Errorf("can't load lock path %v : %w", path, err)
+		}
+
+		if err := yaml.Unmarshal(bs, &lock); err != nil {
+			return lock, fmt.Errorf("can't parse lock path %v : %w", path, err)
+		}
+
+		return lock, nil
+	}
+
+	var enum enumer.EnumData
+
+	if err := processEnum(argsData{InputPath: path}, &enum); err != nil {
+		return compatLock{}, err
+	}
+
+	if err := processFeatures(&enum); err != nil {
+		return compatLock{}, err
+	}
+
+	return compatLock{Type: enum.Type, Features: enum.Features, Values: enum.Values}, nil
+}
+
+// compareCompat reports the differences between two versions of an enum.
+// Values are matched by name, and then by serialized string to find renames
+// among the current values which no old value has by name or already
+// matched.
+func compareCompat(old, current compatLock) []compatChange {
+	var changes []compatChange
+	report := func(severity string, format string, a ...any) {
+		changes = append(changes, compatChange{Severity: severity, Message: fmt.Sprintf(format, a...)})
+	}
+
+	find := func(fn func(enumer.EnumValue) bool) (enumer.EnumValue, bool) {
+		for _, value := range current.Values {
+			if fn(value) {
+				return value, true
+			}
+		}
+
+		return enumer.EnumValue{}, false
+	}
+
+	if old.Type != current.Type {
+		report(severityBreaking, "type renamed from %v to %v", old.Type, current.Type)
+	}
+
+	for _, feature := range old.Features {
+		if !slicer.Contains(feature, current.Features...) {
+			report(severityBreaking, "feature %v removed", feature)
+		}
+	}
+
+	for _, feature := range current.Features {
+		if !slicer.Contains(feature, old.Features...) {
+			report(severityInfo, "feature %v added", feature)
+		}
+	}
+
+	matched := map[string]string{}
+	oldNames := map[string]bool{}
+
+	for _, oldValue := range old.Values {
+		oldNames[oldValue.Name] = true
+	}
+
+	for _, oldValue := range old.Values {
+		value, ok := find(func(v enumer.EnumValue) bool { return v.Name == oldValue.Name })
+
+		if !ok {
+			value, ok = find(func(v enumer.EnumValue) bool {
+				_, taken := matched[v.Name]
+				return v.Serialized == oldValue.Serialized && !taken && !oldNames[v.Name]
+			})
+
+			if !ok {
+				report(severityBreaking, "value %v removed, %q no longer parses", oldValue.Name, oldValue.Serialized)
+				continue
+			}
+
+			report(severityWarning, "value %v renamed to %v", oldValue.Name, value.Name)
+		}
+
+		matched[value.Name] = oldValue.Name
+
+		if value.Serialized != oldValue.Serialized {
+			report(severityBreaking, "value %v serialized changed from %q to %q", oldValue.Name, oldValue.Serialized, value.Serialized)
+		}
+
+		for _, alias := range oldValue.ParseFrom {
+			if !slicer.Contains(alias, value.ParseFrom...) && alias != value.Serialized && alias != value.Name {
+				report(severityBreaking, "value %v parse-from alias %q dropped", oldValue.Name, alias)
+			}
+		}
+
+		if oldValue.ProtoNumber != 0 && value.ProtoNumber != oldValue.ProtoNumber {
+			report(severityBreaking, "value %v proto-number changed from %v to %v", oldValue.Name, oldValue.ProtoNumber, value.ProtoNumber)
+		}
+	}
+
+	for _, value := range current.Values {
+		if _, ok := matched[value.Name]; !ok {
+			report(severityInfo, "value %v added", value.Name)
+		}
+	}
+
+	var oldOrder, currentOrder []string
+
+	for _, value := range rankedValues(enumer.EnumData{Values: old.Values}) {
+		if slicer.Contains(value.Name, mapValues(matched)...) {
+			oldOrder = append(oldOrder, value.Name)
+		}
+	}
+
+	for _, value := range rankedValues(enumer.EnumData{Values: current.Values}) {
+		if name, ok := matched[value.Name]; ok {
+			currentOrder = append(currentOrder, name)
+		}
+	}
+
+	if strings.Join(oldOrder, ",") != strings.Join(currentOrder, ",") {
+		severity := severityInfo
+
+		if slicer.Contains(featureOrdinal, old.Features...) && slicer.Contains(featureOrdinal, current.Features...) {
+			severity = severityBreaking
+		}
+
+		report(severity, "ordinals reordered from %v to %v", strings.Join(oldOrder, ", "), strings.Join(currentOrder, ", "))
+	}
+
+	return changes
+}
+
+func mapValues(m map[string]string) []string {
+	var values []string
+
+	for _, value := range m {
+		values = append(values, value)
+	}
+
+	return values
+}
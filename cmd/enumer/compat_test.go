@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compareCompat(t *testing.T) {
+	rank := func(i int) *int { return &i }
+
+	old := compatLock{
+		Type:     "Severity",
+		Features: []string{featureJson, featureOrdinal},
+		Values: []enumer.EnumValue{
+			{Name: "Low", Serialized: "low", ParseFrom: []string{"l"}, Rank: rank(1)},
+			{Name: "High", Serialized: "high", Rank: rank(2)},
+			{Name: "Urgent", Serialized: "urgent", ProtoNumber: 3, Rank: rank(3)},
+			{Name: "Gone", Serialized: "gone", Rank: rank(4)},
+		},
+	}
+
+	current := compatLock{
+		Type:     "Severity",
+		Features: []string{featureOrdinal, featureYaml},
+		Values: []enumer.EnumValue{
+			{Name: "Low", Serialized: "lo", Rank: rank(2)},
+			{Name: "Top", Serialized: "high", Rank: rank(1)},
+			{Name: "Urgent", Serialized: "urgent", ProtoNumber: 4, Rank: rank(3)},
+			{Name: "New", Serialized: "new", Rank: rank(5)},
+		},
+	}
+
+	assert.Equal(t, []compatChange{
+		{Severity: severityBreaking, Message: "feature json removed"},
+		{Severity: severityInfo, Message: "feature yaml added"},
+		{Severity: severityBreaking, Message: `value Low serialized changed from "low" to "lo"`},
+		{Severity: severityBreaking, Message: `value Low parse-from alias "l" dropped`},
+		{Severity: severityWarning, Message: "value High renamed to Top"},
+		{Severity: severityBreaking, Message: "value Urgent proto-number changed from 3 to 4"},
+		{Severity: severityBreaking, Message: `value Gone removed, "gone" no longer parses`},
+		{Severity: severityInfo, Message: "value New added"},
+		{Severity: severityBreaking, Message: "ordinals reordered from Low, High, Urgent to High, Low, Urgent"},
+	}, compareCompat(old, current))
+
+	assert.Nil(t, compareCompat(current, current))
+}
+
+func Test_compareCompat_renames(t *testing.T) {
+	old := compatLock{
+		Type: "Size",
+		Values: []enumer.EnumValue{
+			{Name: "Small", Serialized: "s"},
+			{Name: "Large", Serialized: "large"},
+			{Name: "Big", Serialized: "big"},
+		},
+	}
+
+	current := compatLock{
+		Type: "Sizes",
+		Values: []enumer.EnumValue{
+			{Name: "Tiny", Serialized: "s"},
+			{Name: "Large", Serialized: "big"},
+		},
+	}
+
+	assert.Equal(t, []compatChange{
+		{Severity: severityBreaking, Message: "type renamed from Size to Sizes"},
+		{Severity: severityWarning, Message: "value Small renamed to Tiny"},
+		{Severity: severityBreaking, Message: `value Large serialized changed from "large" to "big"`},
+		{Severity: severityBreaking, Message: `value Big removed, "big" no longer parses`},
+	}, compareCompat(old, current))
+}
+
+func Test_loadCompat_features(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/a\n\ngo 1.21\n",
+		"color.enum.yaml": `features:
+    - json
+    - iter
+values:
+    - name: Red
+`,
+	})
+
+	_, err := loadCompat(filepath.Join(dir, "color.enum.yaml"))
+
+	assert.ErrorContains(t, err, "invalid feature iter: needs go 1.23 or later")
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/a\n\ngo 1.23\n"), FilePermissions))
+
+	lock, err := loadCompat(filepath.Join(dir, "color.enum.yaml"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{featureJson, featureIter}, lock.Features)
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+	"github.com/gertd/go-pluralize"
+)
+
+type docsArgsData struct {
+	RootPath   string
+	OutputPath string
+	Html       bool
+}
+
+// docsEnum is an enum and where it is documented. Enums are grouped by the
+// import path of their package, or by their directory outside a module, as
+// package names are often shared between directories.
+type docsEnum struct {
+	enum enumer.EnumData
+	path string
+	pkg  string
+}
+
+func processDocs(arguments []string) error {
+	var args docsArgsData
+
+	flags := flag.NewFlagSet("docs", flag.ExitOnError)
+	flags.StringVar(&args.RootPath, "dir", ".", "The directory searched for .enum.yaml files.")
+	flags.StringVar(&args.OutputPath, "output", "docs", "The directory the documentation is written to.")
+	flags.BoolVar(&args.Html, "html", false, "Render HTML in addition to Markdown.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if args.RootPath == "" {
+		return errors.New("missing dir path")
+	}
+
+	if args.OutputPath == "" {
+		return errors.New("missing output path")
+	}
+
+	if absPath, err := filepath.Abs(args.RootPath); err != nil {
+		return err
+	} else {
+		args.RootPath = absPath
+	}
+
+	docs, err := loadDocs(args.RootPath)
+
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if err := writeDoc(args.OutputPath, doc.path+".md", docsMarkdown(doc.enum)); err != nil {
+			return err
+		}
+
+		if args.Html {
+			if err := writeDoc(args.OutputPath, doc.path+".html", docsHtml(doc.enum)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeDoc(args.OutputPath, "index.md", docsIndexMarkdown(docs)); err != nil {
+		return err
+	}
+
+	if args.Html {
+		if err := writeDoc(args.OutputPath, "index.html", docsIndexHtml(docs)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadDocs loads every config under root, sorted by package and path.
+func loadDocs(root string) ([]docsEnum, error) {
+	paths, err := findEnumConfigs(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []docsEnum
+
+	for _, path := range paths {
+		var enum enumer.EnumData
+
+		if err := processEnum(argsData{InputPath: path}, &enum); err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(root, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, err := packageImportPath(filepath.Dir(enum.OutputPath))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg == "" {
+			pkg = filepath.ToSlash(filepath.Dir(rel))
+		}
+
+		rel = stringer.TrimSuffix(rel, ".enum.yaml")
+		docs = append(docs, docsEnum{enum: enum, path: rel, pkg: pkg})
+	}
+
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].pkg == docs[j].pkg {
+			return docs[i].path < docs[j].path
+		}
+
+		return docs[i].pkg < docs[j].pkg
+	})
+
+	return docs, nil
+}
+
+// findEnumConfigs walks root and returns the absolute path of every
+// .enum.yaml file, skipping hidden directories.
+func findEnumConfigs(root string) ([]string, error) {
+	abs, err := filepath.Abs(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+
+	err = filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != abs && stringer.StartsWith(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if stringer.EndsWith(path, ".enum.yaml") {
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	return paths, err
+}
+
+func writeDoc(dir, name string, content string) error {
+	path := filepath.Join(dir, name)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(content), FilePermissions)
+}
+
+type docsRow struct {
+	name       string
+	serialized string
+	parseFrom  []string
+	desc       string
+}
+
+func docsRows(enum enumer.EnumData) []docsRow {
+	var rows []docsRow
+	companionVar := pluralize.NewClient().Plural(enum.Type)
+
+	for _, value := range enum.Values {
+		rows = append(rows, docsRow{
+			name:       companionVar + "." + value.Name,
+			serialized: value.Serialized,
+			parseFrom:  value.ParseFrom,
+			desc:       stringer.TrimSpace(utfer.RemoveNewlines(value.Desc)),
+		})
+	}
+
+	return rows
+}
+
+var docsHeaders = []string{"Go name", "Serialized", "Parse from", "Description"}
+
+func markdownCell(s string) string {
+	return stringer.Replace(s, `\|`, "|")
+}
+
+func markdownCode(ss ...string) string {
+	var codes []string
+
+	for _, s := range ss {
+		codes = append(codes, "`"+markdownCell(s)+"`")
+	}
+
+	return strings.Join(codes, ", ")
+}
+
+func docsMarkdown(enum enumer.EnumData) string {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	writeln("# %s", enum.Type)
+	writeln("")
+	writeln("Package `%s`", enum.Package)
+	writeln("")
+
+	if enum.Desc != "" {
+		writeln("%s", stringer.TrimSpace(utfer.RemoveNewlines(enum.Desc)))
+		writeln("")
+	}
+
+	writeln("| %s |", strings.Join(docsHeaders, " | "))
+	writeln("|%s", strings.Repeat(" --- |", len(docsHeaders)))
+
+	for _, row := range docsRows(enum) {
+		writeln("| %s | %s | %s | %s |",
+			markdownCode(row.name),
+			markdownCode(row.serialized),
+			markdownCode(row.parseFrom...),
+			markdownCell(row.desc),
+		)
+	}
+
+	return sb.String()
+}
+
+func docsIndexMarkdown(docs []docsEnum) string {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	writeln("# Enumerations")
+
+	var current string
+
+	for i, doc := range docs {
+		if doc.pkg != current || i == 0 {
+			current = doc.pkg
+			writeln("")
+			writeln("## %s", current)
+			writeln("")
+		}
+
+		line := fmt.Sprintf("- [%s](%s.md)", doc.enum.Type, filepath.ToSlash(doc.path))
+
+		if doc.enum.Desc != "" {
+			line += " - " + stringer.TrimSpace(utfer.RemoveNewlines(doc.enum.Desc))
+		}
+
+		writeln("%s", line)
+	}
+
+	return sb.String()
+}
+
+func docsHtmlPage(title string, body string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(title), body)
+}
+
+func htmlCode(ss ...string) string {
+	var codes []string
+
+	for _, s := range ss {
+		codes = append(codes, "<code>"+html.EscapeString(s)+"</code>")
+	}
+
+	return strings.Join(codes, ", ")
+}
+
+func docsHtml(enum enumer.EnumData) string {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	writeln("<h1>%s</h1>", html.EscapeString(enum.Type))
+	writeln("<p>Package <code>%s</code></p>", html.EscapeString(enum.Package))
+
+	if enum.Desc != "" {
+		writeln("<p>%s</p>", html.EscapeString(stringer.TrimSpace(utfer.RemoveNewlines(enum.Desc))))
+	}
+
+	writeln("<table>")
+	writeln("<tr>")
+
+	for _, header := range docsHeaders {
+		writeln("<th>%s</th>", html.EscapeString(header))
+	}
+
+	writeln("</tr>")
+
+	for _, row := range docsRows(enum) {
+		writeln("<tr>")
+		writeln("<td>%s</td>", htmlCode(row.name))
+		writeln("<td>%s</td>", htmlCode(row.serialized))
+		writeln("<td>%s</td>", htmlCode(row.parseFrom...))
+		writeln("<td>%s</td>", html.EscapeString(row.desc))
+		writeln("</tr>")
+	}
+
+	writeln("</table>")
+
+	return docsHtmlPage(enum.Type, sb.String())
+}
+
+func docsIndexHtml(docs []docsEnum) string {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	writeln("<h1>Enumerations</h1>")
+
+	var current string
+
+	for i, doc := range docs {
+		if doc.pkg != current || i == 0 {
+			if i > 0 {
+				writeln("</ul>")
+			}
+
+			current = doc.pkg
+			writeln("<h2>%s</h2>", html.EscapeString(current))
+			writeln("<ul>")
+		}
+
+		line := fmt.Sprintf(`<li><a href="%s.html">%s</a>`,
+			html.EscapeString(filepath.ToSlash(doc.path)),
+			html.EscapeString(doc.enum.Type),
+		)
+
+		if doc.enum.Desc != "" {
+			line += " - " + html.EscapeString(stringer.TrimSpace(utfer.RemoveNewlines(doc.enum.Desc)))
+		}
+
+		writeln("%s</li>", line)
+	}
+
+	if len(docs) > 0 {
+		writeln("</ul>")
+	}
+
+	return docsHtmlPage("Enumerations", sb.String())
+}
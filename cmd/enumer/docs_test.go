@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), FilePermissions); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func Test_loadDocs_groups_by_import_path(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/acme\n",
+		"a/color.enum.yaml": `package: shared
+desc: A color
+values:
+    - name: Red
+`,
+		"b/shape.enum.yaml": `package: shared
+values:
+    - name: Square
+`,
+		"b/size.enum.yaml": `package: shared
+values:
+    - name: Small
+`,
+	})
+
+	docs, err := loadDocs(dir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `# Enumerations
+
+## example.com/acme/a
+
+- [Color](a/color.md) - A color
+
+## example.com/acme/b
+
+- [Shape](b/shape.md)
+- [Size](b/size.md)
+`, docsIndexMarkdown(docs))
+
+	assert.Contains(t, docsIndexHtml(docs), `<h2>example.com/acme/a</h2>
+<ul>
+<li><a href="a/color.html">Color</a> - A color</li>
+</ul>
+<h2>example.com/acme/b</h2>`)
+}
+
+func Test_loadDocs_without_module(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, ok := findGoMod(dir); ok {
+		t.Skip("temporary directory is inside a module")
+	}
+
+	writeTestFiles(t, dir, map[string]string{
+		"a/color.enum.yaml": "values:\n    - name: Red\n",
+		"b/color.enum.yaml": "values:\n    - name: Blue\n",
+	})
+
+	docs, err := loadDocs(dir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, []string{docs[0].pkg, docs[1].pkg})
+}
+
+func Test_docsMarkdown(t *testing.T) {
+	enum := enumer.EnumData{
+		Type:    "Op",
+		Package: "ops",
+		Desc:    "An operator",
+		Values: []enumer.EnumValue{
+			{Name: "Or", Serialized: "|", ParseFrom: []string{"or", "||"}, Desc: "Either"},
+		},
+	}
+
+	assert.Equal(t, "# Op\n\nPackage `ops`\n\nAn operator\n\n"+
+		"| Go name | Serialized | Parse from | Description |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| `Ops.Or` | `\\|` | `or`, `\\|\\|` | Either |\n",
+		docsMarkdown(enum))
+
+	assert.Contains(t, docsHtml(enum), "<td><code>|</code></td>")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/mapper"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+)
+
+// processExtends loads the configs enum extends and includes, and returns
+// the values they pass on to it. The extended config's values come first,
+// and enum inherits the settings it leaves out. Included configs only add
+// values, which can't redefine an inherited value. The remove list applies
+// to the inherited values, before enum's own values override them by name.
+func processExtends(args argsData, enum *enumer.EnumData, stack []string) ([]enumer.EnumValue, error) {
+	if enum.Extends == "" && len(enum.Include) == 0 && len(enum.Remove) == 0 {
+		return nil, nil
+	}
+
+	stack = append(stack, args.InputPath)
+
+	var values []enumer.EnumValue
+
+	if enum.Extends != "" {
+		parent, err := loadInherited(args, enum.Extends, stack)
+
+		if err != nil {
+			return nil, err
+		}
+
+		inheritSettings(enum, parent)
+		values = parent.Values
+	}
+
+	for _, path := range enum.Include {
+		included, err := loadInherited(args, path, stack)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range included.Values {
+			if _, ok := findValueName(values, value.Name); ok {
+				return nil, fmt.Errorf("invalid include %v: %v is already inherited", path, value.Name)
+			}
+
+			values = append(values, value)
+		}
+	}
+
+	for _, ref := range enum.Remove {
+		value, err := findValue(enumer.EnumData{Values: values}, ref)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid remove: %w among the inherited values", err)
+		}
+
+		i, _ := findValueName(values, value.Name)
+		values = append(values[:i:i], values[i+1:]...)
+	}
+
+	return values, nil
+}
+
+// loadInherited loads a config enum extends or includes, relative to the
+// config at args.InputPath. Its errors name the config, as they are about
+// another file.
+func loadInherited(args argsData, path string, stack []string) (enumer.EnumData, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(args.InputPath), path)
+	}
+
+	if slicer.Contains(path, stack...) {
+		return enumer.EnumData{}, fmt.Errorf("invalid extends %v: cycle %v", path, strings.Join(append(stack, path), " -> "))
+	}
+
+	var base enumer.EnumData
+
+	if err := loadEnum(argsData{InputPath: path}, &base, stack); err != nil {
+		return enumer.EnumData{}, fmt.Errorf("invalid inherited config %v: %w", path, err)
+	}
+
+	return base, nil
+}
+
+// inheritSettings copies the settings enum leaves out from the config it
+// extends. Names, paths and outputs always come from enum itself.
+func inheritSettings(enum *enumer.EnumData, parent enumer.EnumData) {
+	if enum.Desc == "" {
+		enum.Desc = parent.Desc
+	}
+
+	if enum.Features == nil {
+		enum.Features = parent.Features
+	}
+
+	if enum.Exclude == nil {
+		enum.Exclude = parent.Exclude
+	}
+
+	if enum.Serialize == (enumer.EnumSerialize{}) {
+		enum.Serialize = parent.Serialize
+	}
+
+	if parent.Translate != nil {
+		enum.Translate = mapper.MergeCopy(parent.Translate, enum.Translate)
+	}
+}
+
+func findValueName(values []enumer.EnumValue, name string) (int, bool) {
+	for i, value := range values {
+		if value.Name == name {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
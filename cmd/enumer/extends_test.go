@@ -0,0 +1,129 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processExtends(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		expected []enumer.EnumValue
+		desc     string
+		features []string
+		err      string
+	}{
+		{
+			name: "inherits settings and overrides values",
+			files: map[string]string{
+				"base.enum.yaml": `desc: A currency
+features: [default, tests]
+values:
+    -   name: Dollar
+        serialized: usd
+        desc: A dollar
+        parse-from: [dollar]
+    -   name: Euro
+        desc: Euro
+`,
+				"child.enum.yaml": `extends: base.enum.yaml
+values:
+    -   name: Dollar
+        desc: US dollar
+    -   name: Franc
+`,
+			},
+			expected: []enumer.EnumValue{
+				{Name: "Dollar", Serialized: "usd", Desc: "US dollar", ParseFrom: []string{"dollar"}},
+				{Name: "Euro", Serialized: "euro", Desc: "Euro"},
+				{Name: "Franc", Serialized: "franc"},
+			},
+			desc:     "A currency",
+			features: []string{"default", "tests"},
+		},
+		{
+			name: "removes before own values",
+			files: map[string]string{
+				"base.enum.yaml": `values:
+    -   name: Dollar
+        desc: A dollar
+    -   name: Euro
+`,
+				"child.enum.yaml": `extends: base.enum.yaml
+remove: [Dollar]
+values:
+    -   name: Dollar
+`,
+			},
+			expected: []enumer.EnumValue{
+				{Name: "Euro", Serialized: "euro"},
+				{Name: "Dollar", Serialized: "dollar"},
+			},
+		},
+		{
+			name: "include keeps own settings",
+			files: map[string]string{
+				"base.enum.yaml": "desc: A currency\nvalues:\n    - name: Dollar\n",
+				"child.enum.yaml": `include: [base.enum.yaml]
+values:
+    -   name: Dollar
+        desc: Dollar
+`,
+			},
+			expected: []enumer.EnumValue{
+				{Name: "Dollar", Serialized: "dollar", Desc: "Dollar"},
+			},
+		},
+		{
+			name: "include redefines an inherited value",
+			files: map[string]string{
+				"base.enum.yaml":  "values:\n    - name: Dollar\n",
+				"other.enum.yaml": "values:\n    - name: Dollar\n",
+				"child.enum.yaml": "extends: base.enum.yaml\ninclude: [other.enum.yaml]\n",
+			},
+			err: "invalid include other.enum.yaml: Dollar is already inherited",
+		},
+		{
+			name: "removes an unknown value",
+			files: map[string]string{
+				"base.enum.yaml":  "values:\n    - name: Dollar\n",
+				"child.enum.yaml": "extends: base.enum.yaml\nremove: [Euro]\n",
+			},
+			err: `invalid remove: unknown value "Euro" among the inherited values`,
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"base.enum.yaml":  "extends: child.enum.yaml\n",
+				"child.enum.yaml": "extends: base.enum.yaml\n",
+			},
+			err: "cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			dir := tt.TempDir()
+			writeTestFiles(tt, dir, tc.files)
+
+			var enum enumer.EnumData
+
+			err := processEnum(argsData{InputPath: filepath.Join(dir, "child.enum.yaml")}, &enum)
+
+			if tc.err != "" {
+				assert.ErrorContains(tt, err, tc.err)
+				return
+			}
+
+			assert.Nil(tt, err)
+			assert.Equal(tt, tc.expected, enum.Values)
+			assert.Equal(tt, tc.desc, enum.Desc)
+			assert.Equal(tt, tc.features, enum.Features)
+			assert.Equal(tt, "Child", enum.Type)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+)
+
+const (
+	featureDefault = "default"
+	featureJson    = "json"
+	featureYaml    = "yaml"
+	featureXml     = "xml"
+	featureSql     = "sql"
+	featureIs      = "is"
+	featureMatch   = "match"
+	featureOrdinal = "ordinal"
+	featureSet     = "set"
+	featureMap     = "map"
+	featureIter    = "iter"
+	featureTests   = "tests"
+)
+
+// defaultFeatures are generated when a config doesn't list any features.
+var defaultFeatures = []string{
+	featureJson,
+	featureYaml,
+	featureXml,
+	featureSql,
+	featureIs,
+}
+
+// optionalFeatures are only generated when a config lists them. The iter
+// feature needs a target module with Go 1.23 or later.
+var optionalFeatures = []string{
+	featureMatch,
+	featureOrdinal,
+	featureSet,
+	featureMap,
+	featureIter,
+	featureTests,
+}
+
+// processFeatures resolves the features and exclude lists into the final
+// list of features to generate. The default pseudo feature expands to
+// every default feature, and features the target module can't build are
+// rejected.
+func processFeatures(enum *enumer.EnumData) error {
+	known := append(append([]string{featureDefault}, defaultFeatures...), optionalFeatures...)
+
+	for _, feature := range append(append([]string{}, enum.Features...), enum.Exclude...) {
+		if !slicer.Contains(feature, known...) {
+			return fmt.Errorf("invalid feature %v: must be one of %v", feature, strings.Join(known, ", "))
+		}
+	}
+
+	requested := enum.Features
+
+	if len(requested) == 0 {
+		requested = []string{featureDefault}
+	}
+
+	var features []string
+
+	for _, feature := range requested {
+		if feature == featureDefault {
+			features = append(features, defaultFeatures...)
+		} else {
+			features = append(features, feature)
+		}
+	}
+
+	var resolved []string
+
+	for _, feature := range features {
+		if slicer.Contains(feature, enum.Exclude...) || slicer.Contains(feature, resolved...) {
+			continue
+		}
+
+		resolved = append(resolved, feature)
+	}
+
+	enum.Features = resolved
+	enum.Exclude = nil
+
+	return processIter(enum)
+}
+
+func hasFeature(enum enumer.EnumData, feature string) bool {
+	return slicer.Contains(feature, enum.Features...)
+}
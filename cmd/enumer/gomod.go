@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/version"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// findGoMod returns the path of the nearest go.mod found by walking up from
+// dir, or false if there is none.
+func findGoMod(dir string) (string, bool) {
+	return findUp(dir, "go.mod")
+}
+
+// findUp returns the path of the nearest file called name found by walking
+// up from dir, or false if there is none.
+func findUp(dir string, name string) (string, bool) {
+	for {
+		path := filepath.Join(dir, name)
+
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// goModDirective returns the argument of the first directive named name in
+// the go.mod at path, or an empty string if there is none.
+func goModDirective(path string, name string) (string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 2 && fields[0] == name {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	return "", scanner.Err()
+}
+
+// moduleGoVersion returns the go directive of the nearest go.mod found by
+// walking up from dir as a Go version such as go1.23, and the go.mod path.
+// The version is empty if there is no go.mod or it has no go directive.
+func moduleGoVersion(dir string) (string, string, error) {
+	path, ok := findGoMod(dir)
+
+	if !ok {
+		return "", "", nil
+	}
+
+	directive, err := goModDirective(path, "go")
+
+	if err != nil || directive == "" {
+		return "", path, err
+	}
+
+	goVersion := "go" + directive
+
+	if !version.IsValid(goVersion) {
+		return "", path, fmt.Errorf("invalid go.mod %v: can't parse go %v", path, directive)
+	}
+
+	return goVersion, path, nil
+}
+
+// packageImportPath returns the import path of the package in dir, based on
+// the module path of the nearest go.mod, or an empty string if there is none.
+func packageImportPath(dir string) (string, error) {
+	path, ok := findGoMod(dir)
+
+	if !ok {
+		return "", nil
+	}
+
+	module, err := goModDirective(path, "module")
+
+	if err != nil || module == "" {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(path), dir)
+
+	if err != nil {
+		return "", err
+	}
+
+	if rel == "." {
+		return module, nil
+	}
+
+	return module + "/" + filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/extentioner"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+	"github.com/dave/jennifer/jen"
+)
+
+func processGraphQL(enum *enumer.EnumData) error {
+	if enum.GraphQL == nil {
+		return nil
+	}
+
+	if enum.GraphQL.Name == "" {
+		enum.GraphQL.Name = enum.Type
+	}
+
+	if !graphqlName.MatchString(enum.GraphQL.Name) {
+		return fmt.Errorf("invalid graphql name %v: must match %v", enum.GraphQL.Name, graphqlName)
+	}
+
+	if enum.GraphQL.OutputPath == "" {
+		enum.GraphQL.OutputPath = extentioner.Swap(enum.InputPath, ".yaml", ".graphql")
+	}
+
+	used := map[string]string{}
+
+	for i, value := range enum.Values {
+		name := graphqlIdentifier(value.Serialized)
+
+		if other, ok := used[name]; ok {
+			return fmt.Errorf("invalid values[%v] graphql name %v: already used by %v", i, name, other)
+		}
+
+		used[name] = value.Name
+	}
+
+	return nil
+}
+
+// graphqlName is a GraphQL Name, which is limited to ASCII.
+// https://spec.graphql.org/October2021/#Name
+var graphqlName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// graphqlIdentifier maps a serialized value to a valid GraphQL enum value
+// name, e.g. my-string-1 becomes MY_STRING_1. Anything other than ASCII
+// letters and digits becomes an underscore.
+func graphqlIdentifier(s string) string {
+	var sb strings.Builder
+	underscore := false
+
+	for _, r := range s {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(unicode.ToUpper(r))
+			underscore = false
+		} else if !underscore {
+			sb.WriteRune('_')
+			underscore = true
+		}
+	}
+
+	identifier := strings.TrimRight(sb.String(), "_")
+
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "_" + identifier
+	}
+
+	return identifier
+}
+
+func generateGraphQL(enum enumer.EnumData) []byte {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	for _, line := range enum.HeaderLines {
+		writeln(strings.TrimSpace("# " + line))
+	}
+
+	writeln("")
+
+	if enum.Desc != "" {
+		writeln("%q", utfer.RemoveNewlines(enum.Desc))
+	}
+
+	writeln("enum %s {", enum.GraphQL.Name)
+
+	for _, value := range enum.Values {
+		if value.Desc != "" {
+			writeln("    %q", utfer.RemoveNewlines(value.Desc))
+		}
+
+		writeln("    %s", graphqlIdentifier(value.Serialized))
+	}
+
+	writeln("}")
+
+	return []byte(sb.String())
+}
+
+func templateGraphQL(f *jen.File, enum enumer.EnumData, companionVar string) {
+	if enum.GraphQL == nil {
+		return
+	}
+
+	f.Comment(box("GraphQL marshal/unmarshal implemenation")).Line()
+
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("GraphQL").Params().String().Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+					jen.Return(jen.Lit(graphqlIdentifier(value.Serialized))),
+				)
+			}
+
+			g.Default().Block(
+				jen.Return(jen.String().Params(jen.Id("t"))),
+			)
+		}),
+	).Line()
+
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("MarshalGQL").Params(
+		jen.Id("w").Qual("io", "Writer"),
+	).Block(
+		jen.Qual("fmt", "Fprint").Params(
+			jen.Id("w"),
+			jen.Qual("strconv", "Quote").Params(jen.Id("t").Dot("GraphQL").Call()),
+		),
+	).Line()
+
+	f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("UnmarshalGQL").Params(
+		jen.Id("v").Any(),
+	).Error().Block(
+		jen.Id("s").Op(",").Id("ok").Op(":=").Id("v").Assert(jen.String()).Line(),
+
+		jen.If(jen.Op("!").Id("ok")).Block(
+			jen.Return(jen.Id(companionVar).Dot("errf").Params(jen.Id("v"))),
+		).Line(),
+
+		jen.Id("found").Op(",").Err().Op(":=").
+			Id(companionVar).Dot("Parse").Call(jen.Id("s")).
+			Line(),
+
+		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+
+		jen.Op("*").Id("t").Op("=").Id("found"),
+
+		jen.Return(jen.Nil()),
+	).Line()
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_graphqlIdentifier(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "my-string-1", expected: "MY_STRING_1"},
+		{input: "1st", expected: "_1ST"},
+		{input: "café", expected: "CAF"},
+		{input: "größe", expected: "GR_E"},
+		{input: "日本", expected: "_"},
+		{input: "", expected: "_"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(tt *testing.T) {
+			actual := graphqlIdentifier(tc.input)
+
+			assert.Equal(tt, tc.expected, actual)
+			assert.Regexp(tt, graphqlName, actual)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/langer"
+	"github.com/dave/jennifer/jen"
+)
+
+type enumGroup struct {
+	Name   string
+	Ident  string
+	Values []enumer.EnumValue
+}
+
+// enumGroups returns the groups in the order they're first declared.
+func enumGroups(enum enumer.EnumData) []enumGroup {
+	var groups []enumGroup
+	index := map[string]int{}
+
+	for _, value := range enum.Values {
+		for _, name := range value.Groups {
+			i, ok := index[name]
+
+			if !ok {
+				i = len(groups)
+				index[name] = i
+				groups = append(groups, enumGroup{
+					Name:  name,
+					Ident: langer.Go.MustIdentifier(name),
+				})
+			}
+
+			groups[i].Values = append(groups[i].Values, value)
+		}
+	}
+
+	return groups
+}
+
+// processGroups checks every name generated for a group, the group itself
+// and its Is, Parse and All variants, against the generated members of the
+// companion struct and the enum type, and against the other groups.
+func processGroups(enum *enumer.EnumData) error {
+	members := map[string]string{}
+	methods := map[string]string{}
+
+	for _, name := range companionMembers {
+		members[name] = "a companion member"
+	}
+
+	for _, name := range typeMethods {
+		methods[name] = "a " + enum.Type + " method"
+	}
+
+	for _, value := range enum.Values {
+		members[value.Name] = "value " + value.Name
+	}
+
+	for _, name := range enumAttributes(*enum) {
+		if ident, err := goIdentifier(name); err == nil {
+			methods[ident] = "attribute " + name
+
+			if hasFeature(*enum, featureIter) {
+				members["FilterBy"+ident] = "attribute " + name
+			}
+		}
+	}
+
+	if len(enum.MapsTo) > 0 {
+		mappings, err := enumMappings(*enum)
+
+		if err != nil {
+			return err
+		}
+
+		for _, mapping := range mappings {
+			members["From"+mapping.Target.Type] = "a maps-to conversion"
+			methods["To"+mapping.Target.Type] = "a maps-to conversion"
+		}
+	}
+
+	groups := map[string]string{}
+
+	for i, value := range enum.Values {
+		for _, name := range value.Groups {
+			ident, err := goIdentifier(name)
+
+			if err != nil {
+				return fmt.Errorf("invalid values[%v] group %q: %w", i, name, err)
+			}
+
+			if other, ok := groups[ident]; ok {
+				if other != name {
+					return fmt.Errorf("invalid values[%v] group %v: conflicts with group %v", i, name, other)
+				}
+
+				continue
+			}
+
+			groups[ident] = name
+		}
+	}
+
+	for _, group := range enumGroups(*enum) {
+		generated := []struct {
+			name  string
+			names map[string]string
+		}{
+			{group.Ident, members},
+			{"Parse" + group.Ident, members},
+			{"All" + group.Ident, members},
+			{"Is" + group.Ident, methods},
+		}
+
+		for _, g := range generated {
+			if other, ok := g.names[g.name]; ok {
+				return fmt.Errorf("invalid group %v: %v conflicts with %v", group.Name, g.name, other)
+			}
+		}
+
+		for _, g := range generated {
+			g.names[g.name] = "group " + group.Name
+		}
+	}
+
+	return nil
+}
+
+func templateGroups(f *jen.File, enum enumer.EnumData, companionVar, companionStruct string) {
+	groups := enumGroups(enum)
+
+	if len(groups) == 0 {
+		return
+	}
+
+	f.Comment(box("Groups")).Line()
+
+	for _, group := range groups {
+		f.Commentf("%s returns the %s values in the %s group.", group.Ident, enum.Type, group.Name)
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id(group.Ident).Params().Index().Id(enum.Type).Block(
+			jen.Return(
+				jen.Index().Id(enum.Type).ValuesFunc(func(g *jen.Group) {
+					for _, value := range group.Values {
+						g.Line().Id(companionVar).Dot(value.Name)
+					}
+					g.Line()
+				}),
+			),
+		).Line()
+
+		f.Commentf("Is%s reports whether t is in the %s group.", group.Ident, group.Name)
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Is" + group.Ident).Params().Bool().Block(
+			jen.Switch(jen.Id("t")).Block(
+				jen.CaseFunc(func(g *jen.Group) {
+					for _, value := range group.Values {
+						g.Id(companionVar).Dot(value.Name)
+					}
+				}).Block(jen.Return(jen.True())),
+				jen.Default().Block(jen.Return(jen.False())),
+			),
+		).Line()
+
+		f.Commentf("Parse%s parses v, accepting only values in the %s group.", group.Ident, group.Name)
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Parse"+group.Ident).Params(jen.Id("v").String()).Params(
+			jen.Id(enum.Type),
+			jen.Error(),
+		).Block(
+			jen.Return(jen.Id("t").Dot("ParseFrom").Call(
+				jen.Id("v"),
+				jen.Id("t").Dot(group.Ident).Call().Op("..."),
+			)),
+		).Line()
+
+		if hasFeature(enum, featureIter) {
+			f.Commentf("All%s returns an iterator over the %s values in the %s group.", group.Ident, enum.Type, group.Name)
+			f.Func().Params(jen.Id("t").Id(companionStruct)).Id("All"+group.Ident).Params().Qual("iter", "Seq").Types(jen.Id(enum.Type)).Block(
+				jen.Return(jen.Id("t").Dot("Filter").Call(jen.Id(enum.Type).Dot("Is" + group.Ident))),
+			).Line()
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processGroups(t *testing.T) {
+	testCases := []struct {
+		name   string
+		groups [][]string
+		err    string
+	}{
+		{name: "valid", groups: [][]string{{"metric"}, {"imperial", "nautical"}}},
+		{name: "companion member", groups: [][]string{{"values"}}, err: "Values conflicts with a companion member"},
+		{name: "parse method", groups: [][]string{{"from"}}, err: "ParseFrom conflicts with a companion member"},
+		{name: "is method", groups: [][]string{{"initial"}}, err: "IsInitial conflicts with a Unit method"},
+		{name: "value", groups: [][]string{{"meter"}}, err: "Meter conflicts with value Meter"},
+		{name: "other group", groups: [][]string{{"metric", "parse metric"}}, err: "ParseMetric conflicts with group metric"},
+		{name: "same identifier", groups: [][]string{{"si unit"}, {"SI Unit"}}, err: "conflicts with group si unit"},
+		{name: "symbols", groups: [][]string{{"-"}}, err: `group "-": "-" must contain a letter or digit`},
+		{name: "empty", groups: [][]string{{""}}, err: "must contain a letter or digit"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			enum := enumer.EnumData{Type: "Unit"}
+
+			for i, groups := range tc.groups {
+				enum.Values = append(enum.Values, enumer.EnumValue{
+					Name:   []string{"Meter", "Foot"}[i],
+					Groups: groups,
+				})
+			}
+
+			err := processGroups(&enum)
+
+			if tc.err == "" {
+				assert.Nil(tt, err)
+			} else {
+				assert.ErrorContains(tt, err, tc.err)
+			}
+		})
+	}
+}
+
+func Test_processGroups_attribute_iterator(t *testing.T) {
+	enum := enumer.EnumData{
+		Type:     "Unit",
+		Features: []string{featureIter},
+		Values: []enumer.EnumValue{
+			{Name: "Meter", Groups: []string{"filter by system"}, Attributes: map[string]string{"system": "metric"}},
+		},
+	}
+
+	assert.ErrorContains(t, processGroups(&enum), "FilterBySystem conflicts with attribute system")
+
+	enum.Features = nil
+
+	assert.Nil(t, processGroups(&enum))
+}
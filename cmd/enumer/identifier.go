@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/boundedinfinity/go-commoner/idiomatic/langer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+)
+
+// companionMembers are the fields and methods of every companion struct,
+// besides one field per value.
+var companionMembers = []string{
+	"Err", "Invalid", "Values", "ToStrings", "ParseFrom", "Parse", "IsFrom", "Is",
+	"All", "Enumerate", "Filter", "Min", "Max", "FromOrdinal", "FromProto", "Schema",
+}
+
+// typeMethods are the methods generated on the enum type.
+var typeMethods = []string{
+	"String", "MarshalJSON", "UnmarshalJSON", "MarshalYAML", "UnmarshalYAML", "MarshalXML", "UnmarshalXML",
+	"Value", "Scan", "Is", "Switch", "Ordinal", "Compare", "Less", "Next", "Prev", "Transitions", "CanTransitionTo",
+	"IsInitial", "IsTerminal", "ToProto", "GraphQL", "MarshalGQL", "UnmarshalGQL",
+}
+
+// goIdentifier returns the Go identifier langer.Go makes of s. Unlike
+// langer, which panics on them, it returns an error for names without
+// letters or digits and for names which don't make a valid identifier.
+func goIdentifier(s string) (string, error) {
+	if err := checkIdentifier(s); err != nil {
+		return "", err
+	}
+
+	identifier, err := langer.Go.Identifier(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !token.IsIdentifier(identifier) {
+		return "", fmt.Errorf("%q isn't a valid Go identifier", s)
+	}
+
+	return identifier, nil
+}
+
+// jsonIdentifier returns the JSON identifier langer.Json makes of s, or an
+// error for names without letters or digits.
+func jsonIdentifier(s string) (string, error) {
+	if err := checkIdentifier(s); err != nil {
+		return "", err
+	}
+
+	return langer.Json.Identifier(s)
+}
+
+func checkIdentifier(s string) error {
+	if strings.TrimSpace(utfer.RemoveSymbols(utfer.RemoveNewlines(s))) == "" {
+		return fmt.Errorf("%q must contain a letter or digit", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+	"github.com/boundedinfinity/go-commoner/idiomatic/pather"
+	"gopkg.in/yaml.v3"
+)
+
+type importArgsData struct {
+	InputPath  string
+	OutputPath string
+	Overwrite  bool
+}
+
+// goEnum is a named string or integer type and the constants declared with
+// it, as found in Go source.
+type goEnum struct {
+	Type       string
+	Kind       string
+	Doc        string
+	Directives []string
+	Pos        token.Position
+	Values     []goEnumValue
+}
+
+// goEnumValue is a constant of a goEnum. Value is only set when the
+// constant is a literal, which Literal reports, as an empty string is too.
+type goEnumValue struct {
+	Name       string
+	Value      string
+	Literal    bool
+	Doc        string
+	Directives []string
+}
+
+func processImport(arguments []string) error {
+	var args importArgsData
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.StringVar(&args.InputPath, "input", ".", "The Go file or directory of Go files searched for constant groups.")
+	flags.StringVar(&args.OutputPath, "output", "", "The directory the .enum.yaml files are written to, defaults to the directory of each Go file.")
+	flags.BoolVar(&args.Overwrite, "overwrite", false, "Overwrite existing .enum.yaml files.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	return importGo(args)
+}
+
+// importGo writes a config for every string or integer constant group found
+// in the Go files at args.InputPath, and reports the groups which are
+// skipped.
+func importGo(args importArgsData) error {
+	if args.InputPath == "" {
+		return errors.New("missing input path")
+	}
+
+	info, err := os.Stat(args.InputPath)
+
+	if err != nil {
+		return fmt.Errorf("invalid input path %v: %w", args.InputPath, err)
+	}
+
+	var paths []string
+
+	if info.IsDir() {
+		err := filepath.WalkDir(args.InputPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				if path != args.InputPath && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+				paths = append(paths, path)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	} else {
+		paths = append(paths, args.InputPath)
+	}
+
+	fset := token.NewFileSet()
+
+	for _, path := range paths {
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+
+		if err != nil {
+			return err
+		}
+
+		if ast.IsGenerated(file) {
+			continue
+		}
+
+		dir := args.OutputPath
+
+		if dir == "" {
+			dir = filepath.Dir(path)
+		}
+
+		for _, found := range findGoEnums(fset, file) {
+			if err := importCheck(found); err != nil {
+				fmt.Printf("%v: %v skipped, %v\n", found.Pos, found.Type, err)
+				continue
+			}
+
+			bs, err := importEnum(file.Name.Name, found)
+
+			if err != nil {
+				return err
+			}
+
+			output := filepath.Join(dir, caser.PascalToKebabLower(found.Type)+".enum.yaml")
+
+			if !args.Overwrite && pather.Paths.Exists(output) {
+				fmt.Printf("%v: %v skipped, %v exists\n", found.Pos, found.Type, output)
+				continue
+			}
+
+			if err := writeFile(output, args.Overwrite, bs); err != nil {
+				return err
+			}
+
+			if found.Kind == "int" {
+				fmt.Printf("%v: %v -> %v, replace the %v type and constants before generating\n", found.Pos, found.Type, output, found.Kind)
+			} else {
+				fmt.Printf("%v: %v -> %v\n", found.Pos, found.Type, output)
+			}
+		}
+	}
+
+	return nil
+}
+
+// findGoEnums returns the string and integer types declared in file which
+// have at least one constant. Constants without a type or value inherit
+// them from the previous constant in the group, as iota groups do.
+func findGoEnums(fset *token.FileSet, file *ast.File) []goEnum {
+	var enums []*goEnum
+	index := map[string]*goEnum{}
+
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			ident, ok := spec.Type.(*ast.Ident)
+
+			if !ok || spec.Assign.IsValid() || spec.TypeParams != nil {
+				continue
+			}
+
+			var kind string
+
+			switch ident.Name {
+			case "string":
+				kind = "string"
+			case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+				kind = "int"
+			default:
+				continue
+			}
+
+			doc := spec.Doc
+
+			if doc == nil {
+				doc = decl.Doc
+			}
+
+			found := &goEnum{
+				Type:       spec.Name.Name,
+				Kind:       kind,
+				Doc:        commentText(doc),
+				Directives: commentDirectives(doc),
+				Pos:        fset.Position(spec.Pos()),
+			}
+
+			enums = append(enums, found)
+			index[found.Type] = found
+		}
+	}
+
+	for _, decl := range file.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+
+		if !ok || decl.Tok != token.CONST {
+			continue
+		}
+
+		var typeName string
+		var iotaGroup bool
+
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+
+			if spec.Type != nil {
+				typeName = ""
+
+				if ident, ok := spec.Type.(*ast.Ident); ok {
+					typeName = ident.Name
+				}
+			}
+
+			if len(spec.Values) > 0 {
+				iotaGroup = isIota(spec.Values[0])
+			}
+
+			found, ok := index[typeName]
+
+			if !ok {
+				continue
+			}
+
+			for i, name := range spec.Names {
+				if name.Name == "_" {
+					continue
+				}
+
+				value := goEnumValue{
+					Name:       name.Name,
+					Doc:        commentText(spec.Doc),
+					Directives: append(commentDirectives(spec.Doc), commentDirectives(spec.Comment)...),
+				}
+
+				if i < len(spec.Values) && !iotaGroup {
+					if lit, ok := spec.Values[i].(*ast.BasicLit); ok {
+						switch {
+						case found.Kind == "string" && lit.Kind == token.STRING:
+							value.Value, _ = strconv.Unquote(lit.Value)
+							value.Literal = true
+						case found.Kind == "int" && lit.Kind == token.INT:
+							value.Value = lit.Value
+							value.Literal = true
+						}
+					}
+				}
+
+				if value.Doc == "" {
+					value.Doc = commentText(spec.Comment)
+				}
+
+				found.Values = append(found.Values, value)
+			}
+		}
+	}
+
+	var results []goEnum
+
+	for _, found := range enums {
+		if len(found.Values) > 0 {
+			results = append(results, *found)
+		}
+	}
+
+	return results
+}
+
+func isIota(expr ast.Expr) bool {
+	found := false
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+			found = true
+		}
+		return !found
+	})
+
+	return found
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
+
+// commentDirectives returns the //enumer: directives in group without the
+// leading slashes, e.g. enumer:enum.
+func commentDirectives(group *ast.CommentGroup) []string {
+	if group == nil {
+		return nil
+	}
+
+	var directives []string
+
+	for _, comment := range group.List {
+		if strings.HasPrefix(comment.Text, "//enumer:") {
+			directives = append(directives, strings.TrimPrefix(comment.Text, "//"))
+		}
+	}
+
+	return directives
+}
+
+// goValueName returns the config name of a constant, which loses the type
+// name prefix, e.g. ColorLightBlue becomes Light Blue.
+func goValueName(typeName, constName string) string {
+	name := constName
+
+	if trimmed := strings.TrimPrefix(name, typeName); trimmed != name && trimmed != "" {
+		name = strings.TrimPrefix(trimmed, "_")
+	}
+
+	return caser.PascalToPhrase(name)
+}
+
+// importCheck returns why a Go enum can't be imported without losing its
+// values. String constants need literal values, which become the serialized
+// form, and an empty one can't be written as a serialized value.
+func importCheck(found goEnum) error {
+	if found.Kind != "string" {
+		return nil
+	}
+
+	for _, value := range found.Values {
+		if !value.Literal {
+			return fmt.Errorf("%v isn't a string literal", value.Name)
+		}
+
+		if value.Value == "" {
+			return fmt.Errorf("%v is empty, which can't be a serialized value", value.Name)
+		}
+	}
+
+	return nil
+}
+
+// importEnum builds the config for a Go enum. String constants keep their
+// values as the serialized form, and the Go type they are declared with.
+// Integer constants become strings serialized as the constant names, the
+// way stringer prints them, and keep their order with the ordinal feature.
+// Their integer type has to be replaced by the generated string type.
+func importEnum(pkg string, found goEnum) ([]byte, error) {
+	enum := enumer.EnumData{
+		Type:     found.Type,
+		Package:  pkg,
+		Desc:     found.Doc,
+		SkipType: found.Kind == "string",
+	}
+
+	if found.Kind == "int" {
+		enum.Features = []string{featureDefault, featureOrdinal}
+	}
+
+	for i, value := range found.Values {
+		imported := enumer.EnumValue{
+			Name:       goValueName(found.Type, value.Name),
+			Serialized: value.Value,
+			Desc:       value.Doc,
+			ParseFrom:  directiveArgs(value.Directives, directiveParseFrom),
+		}
+
+		if found.Kind == "int" {
+			imported.Serialized = value.Name
+			imported.Rank = importRank(found, i)
+		}
+
+		enum.Values = append(enum.Values, imported)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(4)
+
+	if err := encoder.Encode(enum); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// importRank returns the rank of the i-th integer constant when every
+// constant is an integer literal, as they may be out of order. Iota groups
+// are ranked by position, which the values list keeps.
+func importRank(found goEnum, i int) *int {
+	for _, value := range found.Values {
+		if !value.Literal {
+			return nil
+		}
+	}
+
+	n, err := strconv.ParseInt(found.Values[i].Value, 0, 0)
+
+	if err != nil {
+		return nil
+	}
+
+	rank := int(n)
+	return &rank
+}
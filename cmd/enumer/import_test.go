@@ -0,0 +1,198 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findGoEnums(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join("testdata", "import", "colors.go"), nil, parser.ParseComments)
+
+	assert.Nil(t, err)
+
+	found := findGoEnums(fset, file)
+
+	var types []string
+
+	for _, enum := range found {
+		types = append(types, enum.Type)
+	}
+
+	assert.Equal(t, []string{"Color", "Level", "Prefixed", "Blank", "Priority"}, types)
+	assert.Equal(t, "Color is the color of a widget.", found[0].Doc)
+	assert.Equal(t, []goEnumValue{
+		{Name: "ColorRed", Value: "red", Literal: true, Doc: "ColorRed is the color of fire."},
+		{Name: "ColorLightBlue", Value: "light-blue", Literal: true, Doc: "The color of the sky."},
+		{Name: "ColorGreen", Value: "green", Literal: true, Directives: []string{"enumer:parse-from g"}},
+	}, found[0].Values)
+
+	assert.Equal(t, "int", found[1].Kind)
+	assert.Equal(t, []string{"LevelDebug", "LevelInfo"}, []string{found[1].Values[0].Name, found[1].Values[1].Name})
+	assert.Nil(t, importCheck(found[0]))
+	assert.Nil(t, importCheck(found[1]))
+	assert.EqualError(t, importCheck(found[2]), "PrefixedA isn't a string literal")
+	assert.EqualError(t, importCheck(found[3]), "BlankNone is empty, which can't be a serialized value")
+	assert.Equal(t, []goEnumValue{
+		{Name: "PriorityHigh", Value: "20", Literal: true},
+		{Name: "PriorityLow", Value: "0x0a", Literal: true},
+	}, found[4].Values)
+}
+
+func Test_importEnum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		found    goEnum
+		expected string
+	}{
+		{
+			name: "string",
+			found: goEnum{
+				Type: "Color",
+				Kind: "string",
+				Doc:  "A color",
+				Values: []goEnumValue{
+					{Name: "ColorLightBlue", Value: "light-blue", Literal: true, Doc: "The sky"},
+					{Name: "Red", Value: "red", Literal: true, Directives: []string{"enumer:parse-from r rouge"}},
+				},
+			},
+			expected: `type: Color
+package: colors
+desc: A color
+skip-type: true
+values:
+    - name: Light Blue
+      desc: The sky
+      serialized: light-blue
+    - name: Red
+      serialized: red
+      parse-from:
+        - r
+        - rouge
+`,
+		},
+		{
+			name: "iota",
+			found: goEnum{
+				Type:   "Level",
+				Kind:   "int",
+				Values: []goEnumValue{{Name: "LevelDebug"}, {Name: "LevelInfo"}},
+			},
+			expected: `type: Level
+package: colors
+features:
+    - default
+    - ordinal
+values:
+    - name: Debug
+      serialized: LevelDebug
+    - name: Info
+      serialized: LevelInfo
+`,
+		},
+		{
+			name: "int literals",
+			found: goEnum{
+				Type: "Priority",
+				Kind: "int",
+				Values: []goEnumValue{
+					{Name: "PriorityHigh", Value: "20", Literal: true},
+					{Name: "PriorityLow", Value: "0x0a", Literal: true},
+				},
+			},
+			expected: `type: Priority
+package: colors
+features:
+    - default
+    - ordinal
+values:
+    - name: High
+      serialized: PriorityHigh
+      rank: 20
+    - name: Low
+      serialized: PriorityLow
+      rank: 10
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			actual, err := importEnum("colors", tc.found)
+
+			assert.Nil(tt, err)
+			assert.Equal(tt, tc.expected, string(actual))
+		})
+	}
+}
+
+func Test_importGo(t *testing.T) {
+	dir := copyTestdata(t, "import")
+	output := filepath.Join(dir, "color.enum.yaml")
+
+	assert.Nil(t, importGo(importArgsData{InputPath: dir}))
+
+	actual, err := os.ReadFile(output)
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(actual), "serialized: light-blue")
+	assert.FileExists(t, filepath.Join(dir, "level.enum.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "priority.enum.yaml"))
+	assert.NoFileExists(t, filepath.Join(dir, "prefixed.enum.yaml"))
+	assert.NoFileExists(t, filepath.Join(dir, "blank.enum.yaml"))
+
+	assert.Nil(t, os.WriteFile(output, []byte("edited"), FilePermissions))
+	assert.Nil(t, importGo(importArgsData{InputPath: dir}))
+
+	actual, err = os.ReadFile(output)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "edited", string(actual))
+
+	assert.Nil(t, importGo(importArgsData{InputPath: dir, Overwrite: true}))
+
+	actual, err = os.ReadFile(output)
+
+	assert.Nil(t, err)
+	assert.Contains(t, string(actual), "type: Color")
+}
+
+// Test_import_compiles imports testdata/import and generates the string enum
+// next to the Go type it was imported from, and runs the package tests.
+func Test_import_compiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	goPath, err := exec.LookPath("go")
+
+	if err != nil {
+		t.Skip("missing go command")
+	}
+
+	dir := copyTestdata(t, "import")
+	sum, err := os.ReadFile(filepath.Join("..", "..", "go.sum"))
+
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.sum"), sum, FilePermissions))
+	assert.Nil(t, importGo(importArgsData{InputPath: dir}))
+
+	var enum enumer.EnumData
+
+	assert.Nil(t, processEnum(argsData{InputPath: filepath.Join(dir, "color.enum.yaml")}, &enum))
+	assert.Nil(t, processGenerate(enum))
+
+	cmd := exec.Command(goPath, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+
+	assert.Nil(t, err, string(out))
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"github.com/dave/jennifer/jen"
+)
+
+func indexFn(enum enumer.EnumData) string {
+	return stringer.ToLowerFirst(enum.Type) + "Index"
+}
+
+// templateIndex generates the function mapping each value to its position in
+// the values list, shared by the types backed by fixed size storage.
+func templateIndex(f *jen.File, enum enumer.EnumData, companionVar string) {
+	if !hasFeature(enum, featureSet) && !hasFeature(enum, featureMap) {
+		return
+	}
+
+	f.Func().Id(indexFn(enum)).Params(jen.Id("t").Id(enum.Type)).Int().Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for i, value := range enum.Values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(jen.Return(jen.Lit(i)))
+			}
+
+			g.Default().Block(jen.Return(jen.Lit(-1)))
+		}),
+	).Line()
+}
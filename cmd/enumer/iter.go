@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"go/version"
+	"path/filepath"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+// iterGoVersion is the first Go version with the iter package.
+const iterGoVersion = "go1.23"
+
+// processIter checks that the module the enum is generated into targets a
+// Go version with the iter package.
+func processIter(enum *enumer.EnumData) error {
+	if !hasFeature(*enum, featureIter) {
+		return nil
+	}
+
+	goVersion, path, err := moduleGoVersion(filepath.Dir(enum.OutputPath))
+
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		return fmt.Errorf("invalid feature %v: needs a go.mod with go 1.23 or later", featureIter)
+	}
+
+	if goVersion == "" {
+		return fmt.Errorf("invalid feature %v: needs go 1.23 or later, %v has no go directive", featureIter, path)
+	}
+
+	if version.Compare(version.Lang(goVersion), iterGoVersion) < 0 {
+		return fmt.Errorf("invalid feature %v: needs go 1.23 or later, %v has go %v", featureIter, path, strings.TrimPrefix(goVersion, "go"))
+	}
+
+	return nil
+}
+
+func templateIter(f *jen.File, enum enumer.EnumData, companionStruct string) {
+	if !hasFeature(enum, featureIter) {
+		return
+	}
+
+	seq := jen.Qual("iter", "Seq").Types(jen.Id(enum.Type))
+	seq2 := jen.Qual("iter", "Seq2").Types(jen.Int(), jen.Id(enum.Type))
+
+	f.Comment(box("Iterators")).Line()
+
+	f.Commentf("All returns an iterator over the %s values.", enum.Type)
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("All").Params().Add(seq).Block(
+		jen.Return(jen.Func().Params(jen.Id("yield").Func().Params(jen.Id(enum.Type)).Bool()).Block(
+			jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("t").Dot("Values").Call()).Block(
+				jen.If(jen.Op("!").Id("yield").Call(jen.Id("item"))).Block(jen.Return()),
+			),
+		)),
+	).Line()
+
+	f.Commentf("Enumerate returns an iterator over the %s values and their positions.", enum.Type)
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Enumerate").Params().Add(seq2).Block(
+		jen.Return(jen.Func().Params(jen.Id("yield").Func().Params(jen.Int(), jen.Id(enum.Type)).Bool()).Block(
+			jen.For(jen.Id("i").Op(",").Id("item").Op(":=").Range().Id("t").Dot("Values").Call()).Block(
+				jen.If(jen.Op("!").Id("yield").Call(jen.Id("i"), jen.Id("item"))).Block(jen.Return()),
+			),
+		)),
+	).Line()
+
+	f.Commentf("Filter returns an iterator over the %s values matching fn.", enum.Type)
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Filter").Params(
+		jen.Id("fn").Func().Params(jen.Id(enum.Type)).Bool(),
+	).Add(seq).Block(
+		jen.Return(jen.Func().Params(jen.Id("yield").Func().Params(jen.Id(enum.Type)).Bool()).Block(
+			jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("t").Dot("Values").Call()).Block(
+				jen.If(jen.Id("fn").Call(jen.Id("item")).Op("&&").Op("!").Id("yield").Call(jen.Id("item"))).Block(jen.Return()),
+			),
+		)),
+	).Line()
+
+	for _, name := range enumAttributes(enum) {
+		ident, err := goIdentifier(name)
+
+		if err != nil {
+			continue
+		}
+
+		f.Commentf("FilterBy%s returns an iterator over the %s values whose %s attribute is v.", ident, enum.Type, name)
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id("FilterBy" + ident).Params(jen.Id("v").String()).Add(seq).Block(
+			jen.Return(jen.Id("t").Dot("Filter").Call(
+				jen.Func().Params(jen.Id("item").Id(enum.Type)).Bool().Block(
+					jen.Return(jen.Id("item").Dot(ident).Call().Op("==").Id("v")),
+				),
+			)),
+		).Line()
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+// copyTestdata copies testdata/name into a temporary directory, so generated
+// files stay out of the tree.
+func copyTestdata(t *testing.T, name string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	src := filepath.Join("testdata", name)
+
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		bs, err := os.ReadFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(filepath.Join(dir, rel), bs, FilePermissions)
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func Test_processIter(t *testing.T) {
+	testCases := []struct {
+		name  string
+		goMod string
+		err   string
+	}{
+		{name: "go 1.21", goMod: "module example.com/a\n\ngo 1.21\n", err: "invalid feature iter: needs go 1.23 or later"},
+		{name: "go 1.23", goMod: "module example.com/a\n\ngo 1.23\n"},
+		{name: "go 1.23rc1", goMod: "module example.com/a\n\ngo 1.23rc1\n"},
+		{name: "go 1.24.1", goMod: "module example.com/a\n\ngo 1.24.1\n"},
+		{name: "go 1.22rc1", goMod: "module example.com/a\n\ngo 1.22rc1\n", err: "has go 1.22rc1"},
+		{name: "no go directive", goMod: "module example.com/a\n", err: "has no go directive"},
+		{name: "invalid go directive", goMod: "module example.com/a\n\ngo 1.x\n", err: "can't parse go 1.x"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			dir := tt.TempDir()
+			assert.Nil(tt, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(tc.goMod), FilePermissions))
+
+			enum := enumer.EnumData{
+				OutputPath: filepath.Join(dir, "color.enum.go"),
+				Features:   []string{featureDefault, featureIter},
+			}
+
+			err := processFeatures(&enum)
+
+			if tc.err != "" {
+				assert.ErrorContains(tt, err, tc.err)
+				return
+			}
+
+			assert.Nil(tt, err)
+			assert.True(tt, hasFeature(enum, featureIter))
+		})
+	}
+}
+
+func Test_processIter_without_module(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, ok := findGoMod(dir); ok {
+		t.Skip("temporary directory is inside a module")
+	}
+
+	enum := enumer.EnumData{
+		OutputPath: filepath.Join(dir, "color.enum.go"),
+		Features:   []string{featureIter},
+	}
+
+	assert.ErrorContains(t, processFeatures(&enum), "invalid feature iter: needs a go.mod with go 1.23 or later")
+}
+
+// Test_iter_compiles generates testdata/iter, a Go 1.23 module, and runs its
+// tests, which use the iterators.
+func Test_iter_compiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+
+	goPath, err := exec.LookPath("go")
+
+	if err != nil {
+		t.Skip("missing go command")
+	}
+
+	dir := copyTestdata(t, "iter")
+	sum, err := os.ReadFile(filepath.Join("..", "..", "go.sum"))
+
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "go.sum"), sum, FilePermissions))
+
+	var enum enumer.EnumData
+
+	assert.Nil(t, processEnum(argsData{InputPath: filepath.Join(dir, "color.enum.yaml")}, &enum))
+	assert.Nil(t, processGenerate(enum))
+
+	cmd := exec.Command(goPath, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	out, err := cmd.CombinedOutput()
+
+	assert.Nil(t, err, string(out))
+}
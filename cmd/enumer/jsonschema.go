@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+)
+
+// jsonSchemaEnums are the named lists an enum tag can refer to. Any other
+// enum tag is a comma separated list.
+func jsonSchemaEnums() map[string][]string {
+	return map[string][]string{
+		"converters": caser.ConverterCombinations(),
+		"features":   append(append([]string{featureDefault}, defaultFeatures...), optionalFeatures...),
+	}
+}
+
+// generateJsonSchema builds the schema for enum configs from the yaml, desc,
+// enum and required tags of the enumer.EnumData model. Fields tagged
+// schema:"-" are set by the generator, and left out.
+func generateJsonSchema() string {
+	m := jsonSchemaType(reflect.TypeOf(enumer.EnumData{}))
+	m["$schema"] = "http://json-schema.org/draft-07/schema"
+	m["title"] = "Bounded Infinity enumeration tool"
+
+	bs, err := json.MarshalIndent(m, "", "    ")
+
+	if err != nil {
+		panic(err)
+	}
+
+	return string(bs) + "\n"
+}
+
+func jsonSchemaType(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchemaType(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchemaType(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchemaType(t.Elem())}
+	case reflect.Struct:
+		return jsonSchemaStruct(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+func jsonSchemaStruct(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required, anyOf []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+		if name == "" || name == "-" || !field.IsExported() || field.Tag.Get("schema") == "-" {
+			continue
+		}
+
+		property := jsonSchemaType(field.Type)
+
+		if desc := field.Tag.Get("desc"); desc != "" {
+			property["description"] = desc
+		}
+
+		if tag := field.Tag.Get("enum"); tag != "" {
+			values, ok := jsonSchemaEnums()[tag]
+
+			if !ok {
+				values = strings.Split(tag, ",")
+			}
+
+			if items, ok := property["items"].(map[string]any); ok {
+				items["enum"] = values
+			} else {
+				property["enum"] = values
+			}
+		}
+
+		switch field.Tag.Get("required") {
+		case "true":
+			required = append(required, name)
+		case "any":
+			anyOf = append(anyOf, name)
+		}
+
+		properties[name] = property
+	}
+
+	m := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		m["required"] = required
+	}
+
+	if len(anyOf) > 0 {
+		var rules []map[string]any
+
+		for _, name := range anyOf {
+			rules = append(rules, map[string]any{"required": []string{name}})
+		}
+
+		m["anyOf"] = rules
+	}
+
+	return m
+}
+
+type schemaArgsData struct {
+	OutputPath string
+}
+
+func processSchemaCommand(arguments []string) error {
+	var args schemaArgsData
+
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	flags.StringVar(&args.OutputPath, "output", "", "The file the JSON schema is written to, defaults to stdout.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if len(flags.Args()) > 0 {
+		return errors.New("unexpected arguments: " + strings.Join(flags.Args(), " "))
+	}
+
+	if args.OutputPath == "" {
+		_, err := os.Stdout.WriteString(generateJsonSchema())
+		return err
+	}
+
+	return os.WriteFile(args.OutputPath, []byte(generateJsonSchema()), FilePermissions)
+}
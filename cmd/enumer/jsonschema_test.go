@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_generateJsonSchema_files(t *testing.T) {
+	expected := generateJsonSchema()
+
+	for _, path := range []string{
+		jsonSchemaName,
+		filepath.Join("..", "..", ".vscode", jsonSchemaName),
+	} {
+		actual, err := os.ReadFile(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, string(actual), "%v is out of date, regenerate it with enumer json-schema", path)
+	}
+
+	assert.NotContains(t, expected, `"input-path"`)
+	assert.NotContains(t, expected, `"header-lines"`)
+	assert.NotContains(t, expected, `"debug"`)
+}
@@ -0,0 +1,554 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+	"github.com/gertd/go-pluralize"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// The subset of the Language Server Protocol used by the lsp command.
+// https://microsoft.github.io/language-server-protocol/specifications/specification-current/
+
+const (
+	lspMethodNotFound      = -32601
+	lspInternalError       = -32603
+	lspSeverityError       = 1
+	lspCompletionKindValue = 12
+	lspSyncFull            = 1
+)
+
+type lspRequest struct {
+	Id     *json.RawMessage `json:"id,omitempty"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JsonRpc string           `json:"jsonrpc"`
+	Id      *json.RawMessage `json:"id"`
+	Result  any              `json:"result"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspNotification struct {
+	JsonRpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	Uri   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspTextDocumentParams struct {
+	TextDocument struct {
+		Uri  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+	Position lspPosition `json:"position"`
+}
+
+// lspEntry is a values item in a config and the lines it spans.
+type lspEntry struct {
+	Start      int
+	End        int
+	Name       string
+	Serialized string
+}
+
+type lspServer struct {
+	reader *bufio.Reader
+	writer io.Writer
+	docs   map[string]string
+}
+
+func processLsp(arguments []string) error {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	server := lspServer{
+		reader: bufio.NewReader(os.Stdin),
+		writer: os.Stdout,
+		docs:   map[string]string{},
+	}
+
+	return server.serve()
+}
+
+func (t *lspServer) serve() error {
+	for {
+		bs, err := t.read()
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var request lspRequest
+
+		if err := json.Unmarshal(bs, &request); err != nil {
+			return err
+		}
+
+		if request.Method == "exit" {
+			return nil
+		}
+
+		if err := t.handle(request); err != nil {
+			return err
+		}
+	}
+}
+
+// handle answers a request or a notification. A panic while handling it is
+// reported to the client instead of stopping the server.
+func (t *lspServer) handle(request lspRequest) (err error) {
+	var params lspTextDocumentParams
+	_ = json.Unmarshal(request.Params, &params)
+	uri := params.TextDocument.Uri
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = t.recovered(request, uri, r)
+		}
+	}()
+
+	switch request.Method {
+	case "initialize":
+		return t.respond(request, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync":   lspSyncFull,
+				"hoverProvider":      true,
+				"definitionProvider": true,
+				"completionProvider": map[string]any{
+					"triggerCharacters": []string{" ", ":"},
+				},
+			},
+			"serverInfo": map[string]any{"name": "enumer"},
+		})
+	case "shutdown":
+		return t.respond(request, nil)
+	case "textDocument/didOpen":
+		t.docs[uri] = params.TextDocument.Text
+		return t.diagnose(uri)
+	case "textDocument/didChange":
+		if len(params.ContentChanges) > 0 {
+			t.docs[uri] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		}
+		return t.diagnose(uri)
+	case "textDocument/didSave":
+		return t.diagnose(uri)
+	case "textDocument/didClose":
+		delete(t.docs, uri)
+		return t.notify("textDocument/publishDiagnostics", map[string]any{
+			"uri":         uri,
+			"diagnostics": []lspDiagnostic{},
+		})
+	case "textDocument/hover":
+		return t.respond(request, t.hover(uri, params.Position))
+	case "textDocument/completion":
+		return t.respond(request, t.completion(uri, params.Position))
+	case "textDocument/definition":
+		return t.respond(request, t.definition(uri, params.Position))
+	default:
+		if request.Id == nil {
+			return nil
+		}
+
+		return t.write(lspResponse{
+			JsonRpc: "2.0",
+			Id:      request.Id,
+			Error:   &lspError{Code: lspMethodNotFound, Message: "method not found: " + request.Method},
+		})
+	}
+}
+
+// recovered reports a panic while handling request: as an error response to
+// a request, and as a diagnostic of the document a notification was about.
+func (t *lspServer) recovered(request lspRequest, uri string, r any) error {
+	message := fmt.Sprintf("internal error: %v", r)
+
+	if request.Id != nil {
+		return t.write(lspResponse{
+			JsonRpc: "2.0",
+			Id:      request.Id,
+			Error:   &lspError{Code: lspInternalError, Message: message},
+		})
+	}
+
+	if _, ok := t.docs[uri]; !ok {
+		return nil
+	}
+
+	return t.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri": uri,
+		"diagnostics": []lspDiagnostic{{
+			Range:    lspLineRange(t.docs[uri], 0),
+			Severity: lspSeverityError,
+			Source:   "enumer",
+			Message:  message,
+		}},
+	})
+}
+
+func (t *lspServer) read() ([]byte, error) {
+	length := -1
+
+	for {
+		line, err := t.reader.ReadString('\n')
+
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length %v: %w", value, err)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length")
+	}
+
+	bs := make([]byte, length)
+	_, err := io.ReadFull(t.reader, bs)
+	return bs, err
+}
+
+func (t *lspServer) write(message any) error {
+	bs, err := json.Marshal(message)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(t.writer, "Content-Length: %d\r\n\r\n%s", len(bs), bs)
+	return err
+}
+
+func (t *lspServer) respond(request lspRequest, result any) error {
+	return t.write(lspResponse{JsonRpc: "2.0", Id: request.Id, Result: result})
+}
+
+func (t *lspServer) notify(method string, params any) error {
+	return t.write(lspNotification{JsonRpc: "2.0", Method: method, Params: params})
+}
+
+// load runs the same validation as generating, without writing anything.
+func (t *lspServer) load(uri string) (enumer.EnumData, error) {
+	var enum enumer.EnumData
+
+	path, err := lspPath(uri)
+
+	if err != nil {
+		return enum, err
+	}
+
+	if err := loadEnumBytes(argsData{InputPath: path}, []byte(t.docs[uri]), &enum, nil); err != nil {
+		return enum, err
+	}
+
+	if err := processValidate(&enum, false); err != nil {
+		return enum, err
+	}
+
+	_, err = processTemplate(enum)
+	return enum, err
+}
+
+var (
+	lspYamlLine   = regexp.MustCompile(`yaml: line (\d+)`)
+	lspValueIndex = regexp.MustCompile(`^invalid values\[(\d+)\]`)
+)
+
+func (t *lspServer) diagnose(uri string) error {
+	diagnostics := []lspDiagnostic{}
+
+	if enum, err := t.load(uri); err != nil {
+		line := 0
+		message := err.Error()
+
+		if match := lspYamlLine.FindStringSubmatch(message); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			line--
+		} else if match := lspValueIndex.FindStringSubmatch(message); match != nil {
+			i, _ := strconv.Atoi(match[1])
+			line = lspValueLine(enum, lspEntries(t.docs[uri]), i)
+		}
+
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    lspLineRange(t.docs[uri], line),
+			Severity: lspSeverityError,
+			Source:   "enumer",
+			Message:  message,
+		})
+	}
+
+	return t.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+// lspValueLine returns the line of the values item an error about values[i]
+// is about. Values which didn't normalize are indexed by the values list,
+// which leaves enum without values. Otherwise the index is among all the
+// values, including the inherited ones and the values-from rows, and the item
+// is found by name, or the error is reported at the top when the value
+// isn't listed.
+func lspValueLine(enum enumer.EnumData, entries []lspEntry, i int) int {
+	if len(enum.Values) == 0 {
+		if i < len(entries) {
+			return entries[i].Start
+		}
+
+		return 0
+	}
+
+	if i >= len(enum.Values) {
+		return 0
+	}
+
+	for _, entry := range entries {
+		if value, err := findValue(enum, entry.Name); err == nil && value.Name == enum.Values[i].Name {
+			return entry.Start
+		}
+	}
+
+	return 0
+}
+
+// value returns the enum value of the values item at position.
+func (t *lspServer) value(uri string, position lspPosition) (enumer.EnumData, enumer.EnumValue, bool) {
+	enum, err := t.load(uri)
+
+	if err != nil {
+		return enum, enumer.EnumValue{}, false
+	}
+
+	for _, entry := range lspEntries(t.docs[uri]) {
+		if position.Line < entry.Start || position.Line > entry.End {
+			continue
+		}
+
+		for _, ref := range []string{entry.Name, entry.Serialized} {
+			if ref == "" {
+				continue
+			}
+
+			if value, err := findValue(enum, ref); err == nil {
+				return enum, value, true
+			}
+		}
+	}
+
+	return enum, enumer.EnumValue{}, false
+}
+
+func (t *lspServer) hover(uri string, position lspPosition) any {
+	enum, value, ok := t.value(uri, position)
+
+	if !ok {
+		return nil
+	}
+
+	companionVar := pluralize.NewClient().Plural(enum.Type)
+	contents := fmt.Sprintf("```go\n%s.%s\n```\n\nserialized: `%s`", companionVar, value.Name, value.Serialized)
+
+	return map[string]any{
+		"contents": map[string]any{"kind": "markdown", "value": contents},
+	}
+}
+
+var lspConverterKey = regexp.MustCompile(`^\s+(type|value):\s*\S*$`)
+
+// completion offers the converter names for the serialize type and value.
+func (t *lspServer) completion(uri string, position lspPosition) any {
+	lines := strings.Split(t.docs[uri], "\n")
+
+	if position.Line >= len(lines) {
+		return []any{}
+	}
+
+	line := lines[position.Line]
+
+	if position.Character < len(line) {
+		line = line[:position.Character]
+	}
+
+	if !lspConverterKey.MatchString(line) {
+		return []any{}
+	}
+
+	var items []map[string]any
+
+	for _, name := range caser.ConverterCombinations() {
+		items = append(items, map[string]any{"label": name, "kind": lspCompletionKindValue})
+	}
+
+	return items
+}
+
+// definition goes from a values item to its field in the generated companion
+// struct, and from the type key to the generated type.
+func (t *lspServer) definition(uri string, position lspPosition) any {
+	lines := strings.Split(t.docs[uri], "\n")
+	var pattern *regexp.Regexp
+	var enum enumer.EnumData
+
+	if position.Line < len(lines) && strings.HasPrefix(lines[position.Line], "type:") {
+		var err error
+
+		if enum, err = t.load(uri); err != nil {
+			return nil
+		}
+
+		pattern = regexp.MustCompile(`^type ` + regexp.QuoteMeta(enum.Type) + `\b`)
+	} else {
+		found, value, ok := t.value(uri, position)
+
+		if !ok {
+			return nil
+		}
+
+		enum = found
+		pattern = regexp.MustCompile(`^\s+` + regexp.QuoteMeta(value.Name) + `:\s+` + regexp.QuoteMeta(enum.Type) + `\(`)
+	}
+
+	bs, err := os.ReadFile(enum.OutputPath)
+
+	if err != nil {
+		return nil
+	}
+
+	generated := string(bs)
+
+	for i, line := range strings.Split(generated, "\n") {
+		if loc := pattern.FindStringIndex(line); loc != nil {
+			return lspLocation{
+				Uri:   (&url.URL{Scheme: "file", Path: enum.OutputPath}).String(),
+				Range: lspLineRange(generated, i),
+			}
+		}
+	}
+
+	return nil
+}
+
+func lspPath(uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Scheme != "file" {
+		return "", fmt.Errorf("invalid uri %v: must be a file", uri)
+	}
+
+	return parsed.Path, nil
+}
+
+func lspLineRange(text string, line int) lspRange {
+	lines := strings.Split(text, "\n")
+	end := 0
+
+	if line >= 0 && line < len(lines) {
+		end = len(lines[line])
+	}
+
+	return lspRange{
+		Start: lspPosition{Line: line},
+		End:   lspPosition{Line: line, Character: end},
+	}
+}
+
+// lspEntries returns the items of the values list with zero based lines.
+// Each item spans to the line before the next one.
+func lspEntries(text string) []lspEntry {
+	var doc yamlv3.Node
+
+	if err := yamlv3.Unmarshal([]byte(text), &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+
+	values := yamlNodeValue(doc.Content[0], "values")
+
+	if values == nil || values.Kind != yamlv3.SequenceNode {
+		return nil
+	}
+
+	var entries []lspEntry
+
+	for i, item := range values.Content {
+		entry := lspEntry{Start: item.Line - 1, End: strings.Count(text, "\n")}
+
+		if i+1 < len(values.Content) {
+			entry.End = values.Content[i+1].Line - 2
+		}
+
+		if name := yamlNodeValue(item, "name"); name != nil {
+			entry.Name = name.Value
+		}
+
+		if serialized := yamlNodeValue(item, "serialized"); serialized != nil {
+			entry.Serialized = serialized.Value
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
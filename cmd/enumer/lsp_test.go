@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+	"github.com/stretchr/testify/assert"
+)
+
+func lspMessages(messages ...any) string {
+	var text strings.Builder
+
+	for _, message := range messages {
+		bs, _ := json.Marshal(message)
+		fmt.Fprintf(&text, "Content-Length: %d\r\n\r\n%s", len(bs), bs)
+	}
+
+	return text.String()
+}
+
+func lspTestServer(input string) (*lspServer, *bytes.Buffer) {
+	var output bytes.Buffer
+
+	return &lspServer{
+		reader: bufio.NewReader(strings.NewReader(input)),
+		writer: &output,
+		docs:   map[string]string{},
+	}, &output
+}
+
+func Test_lsp_invalid_name(t *testing.T) {
+	uri := "file://" + filepath.Join(t.TempDir(), "color.enum.yaml")
+	server, output := lspTestServer(lspMessages(
+		map[string]any{
+			"method": "textDocument/didOpen",
+			"params": map[string]any{"textDocument": map[string]any{
+				"uri":  uri,
+				"text": "values:\n    -   name: Red\n    -   name: \"!!!\"\n",
+			}},
+		},
+		map[string]any{
+			"id":     1,
+			"method": "textDocument/hover",
+			"params": map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 1}},
+		},
+	))
+
+	assert.Nil(t, server.serve())
+	assert.Contains(t, output.String(), `"line":2`)
+	assert.Contains(t, output.String(), `invalid values[1] name: \"!!!\" must contain a letter or digit`)
+	assert.Contains(t, output.String(), `{"jsonrpc":"2.0","id":1,"result":null}`)
+}
+
+func Test_lsp_recovered(t *testing.T) {
+	server, output := lspTestServer("")
+	server.docs["file:///color.enum.yaml"] = "values:\n"
+	id := json.RawMessage("7")
+
+	assert.Nil(t, server.recovered(lspRequest{Id: &id, Method: "textDocument/hover"}, "file:///color.enum.yaml", "boom"))
+	assert.Contains(t, output.String(), `{"jsonrpc":"2.0","id":7,"result":null,"error":{"code":-32603,"message":"internal error: boom"}}`)
+
+	output.Reset()
+
+	assert.Nil(t, server.recovered(lspRequest{Method: "textDocument/didChange"}, "file:///color.enum.yaml", "boom"))
+	assert.Contains(t, output.String(), `"uri":"file:///color.enum.yaml"`)
+	assert.Contains(t, output.String(), `"message":"internal error: boom"`)
+
+	output.Reset()
+
+	assert.Nil(t, server.recovered(lspRequest{Method: "textDocument/didChange"}, "file:///other.enum.yaml", "boom"))
+	assert.Empty(t, output.String())
+}
+
+func Test_lsp_diagnose_values(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"base.enum.yaml": "features:\n    - default\n    - ordinal\nvalues:\n    -   name: Low\n        rank: 1\n",
+		"names.csv":      "name\n!!!\n",
+	})
+
+	testCases := []struct {
+		name    string
+		text    string
+		line    int
+		message string
+	}{
+		{
+			name:    "own value",
+			text:    "values:\n    -   name: Red\n    -   name: \"!!!\"\n",
+			line:    2,
+			message: `invalid values[1] name: \"!!!\" must contain a letter or digit`,
+		},
+		{
+			name:    "after inherited values",
+			text:    "extends: base.enum.yaml\nvalues:\n    -   name: Medium\n        rank: 2\n    -   name: High\n        rank: 1\n",
+			line:    4,
+			message: "invalid values[2] rank 1: already used by Low",
+		},
+		{
+			name:    "values-from row",
+			text:    "values-from:\n    path: names.csv\nvalues:\n    -   name: Red\n",
+			line:    0,
+			message: `invalid values-from[0] name: \"!!!\" must contain a letter or digit`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			uri := "file://" + filepath.Join(dir, "color.enum.yaml")
+			server, output := lspTestServer("")
+			server.docs[uri] = tc.text
+
+			assert.Nil(tt, server.diagnose(uri))
+			assert.Contains(tt, output.String(), fmt.Sprintf(`"range":{"start":{"line":%d,`, tc.line))
+			assert.Contains(tt, output.String(), tc.message)
+		})
+	}
+}
+
+func Test_lsp_hover(t *testing.T) {
+	uri := "file://" + filepath.Join(t.TempDir(), "color.enum.yaml")
+	server, _ := lspTestServer("")
+	server.docs[uri] = "values:\n    -   name: Light Blue\n        serialized: lb\n    -   name: Red\n"
+
+	assert.Equal(t, map[string]any{
+		"contents": map[string]any{"kind": "markdown", "value": "```go\nColors.LightBlue\n```\n\nserialized: `lb`"},
+	}, server.hover(uri, lspPosition{Line: 2}))
+	assert.Equal(t, map[string]any{
+		"contents": map[string]any{"kind": "markdown", "value": "```go\nColors.Red\n```\n\nserialized: `red`"},
+	}, server.hover(uri, lspPosition{Line: 3}))
+	assert.Nil(t, server.hover(uri, lspPosition{Line: 0}))
+}
+
+func Test_lsp_completion(t *testing.T) {
+	uri := "file:///color.enum.yaml"
+	server, _ := lspTestServer("")
+	server.docs[uri] = "serialize:\n    type: \n    value: kebab\ndesc: x\n"
+
+	var labels []string
+
+	items, ok := server.completion(uri, lspPosition{Line: 1, Character: 10}).([]map[string]any)
+
+	assert.True(t, ok)
+
+	for _, item := range items {
+		labels = append(labels, item["label"].(string))
+	}
+
+	assert.ElementsMatch(t, caser.ConverterCombinations(), labels)
+	assert.NotEmpty(t, server.completion(uri, lspPosition{Line: 2, Character: 16}))
+	assert.Equal(t, []any{}, server.completion(uri, lspPosition{Line: 3, Character: 7}))
+	assert.Equal(t, []any{}, server.completion(uri, lspPosition{Line: 9}))
+}
+
+func Test_lsp_definition(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "colors")
+	path := filepath.Join(dir, "color.enum.yaml")
+	text := "type: Color\nvalues:\n    -   name: Red\n    -   name: Light Blue\n"
+
+	writeTestFiles(t, dir, map[string]string{"color.enum.yaml": text})
+
+	var enum enumer.EnumData
+
+	assert.Nil(t, processEnum(argsData{InputPath: path}, &enum))
+	assert.Nil(t, processGenerate(enum))
+
+	uri := "file://" + path
+	server, _ := lspTestServer("")
+	server.docs[uri] = text
+
+	bs, err := os.ReadFile(enum.OutputPath)
+
+	assert.Nil(t, err)
+
+	lineOf := func(prefix string) int {
+		for i, line := range strings.Split(string(bs), "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), prefix) {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	generated := (&url.URL{Scheme: "file", Path: enum.OutputPath}).String()
+
+	location, ok := server.definition(uri, lspPosition{Line: 3}).(lspLocation)
+
+	assert.True(t, ok)
+	assert.Equal(t, generated, location.Uri)
+	assert.Equal(t, lineOf("LightBlue: Color("), location.Range.Start.Line)
+
+	location, ok = server.definition(uri, lspPosition{Line: 0}).(lspLocation)
+
+	assert.True(t, ok)
+	assert.Equal(t, lineOf("type Color "), location.Range.Start.Line)
+	assert.Nil(t, server.definition(uri, lspPosition{Line: 1}))
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	_ "embed"
-	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -10,12 +9,12 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/boundedinfinity/asciibox"
 	"github.com/boundedinfinity/enumer"
 	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
 	"github.com/boundedinfinity/go-commoner/idiomatic/extentioner"
-	"github.com/boundedinfinity/go-commoner/idiomatic/langer"
 	"github.com/boundedinfinity/go-commoner/idiomatic/mapper"
 	"github.com/boundedinfinity/go-commoner/idiomatic/pather"
 	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
@@ -25,6 +24,9 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+//go:generate go run . schema -output enum.schema.json
+//go:generate go run . schema -output ../../.vscode/enum.schema.json
+
 //go:embed settings.json
 var vscodeSettingsContext string
 
@@ -46,12 +48,15 @@ var (
 )
 
 type argsData struct {
-	InputPath  string
-	SkipFormat bool
-	Debug      bool
-	VsCode     string
-	Serialize  string
-	Overwrite  bool
+	InputPath     string
+	SkipFormat    bool
+	Debug         bool
+	VsCode        string
+	SourcePath    string
+	WatchPath     string
+	WatchInterval time.Duration
+	Serialize     string
+	Overwrite     bool
 }
 
 func handleErr(err error) {
@@ -62,6 +67,29 @@ func handleErr(err error) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "docs":
+			handleErr(processDocs(os.Args[2:]))
+			return
+		case "import":
+			handleErr(processImport(os.Args[2:]))
+			return
+		case "compat":
+			handleErr(processCompat(os.Args[2:]))
+			return
+		case "lsp":
+			handleErr(processLsp(os.Args[2:]))
+			return
+		case "schema":
+			handleErr(processSchemaCommand(os.Args[2:]))
+			return
+		case "dot":
+			handleErr(processDot(os.Args[2:]))
+			return
+		}
+	}
+
 	var args argsData
 
 	if err := processArgs(&args); err != nil {
@@ -72,6 +100,18 @@ func main() {
 		if err := processJsonSchema(args); err != nil {
 			handleErr(err)
 		}
+	} else if args.WatchPath != "" {
+		handleErr(processWatch(args))
+	} else if args.SourcePath != "" {
+		enums, err := processSource(args)
+
+		if err != nil {
+			handleErr(err)
+		}
+
+		for _, enum := range enums {
+			handleErr(processGenerate(enum))
+		}
 	} else {
 		var enum enumer.EnumData
 
@@ -79,86 +119,107 @@ func main() {
 			handleErr(err)
 		}
 
-		bs, err := processTemplate(enum)
+		handleErr(processGenerate(enum))
+	}
+}
+
+// processGenerate runs every step after the config is loaded, and writes the
+// enum and all the files generated alongside it.
+func processGenerate(enum enumer.EnumData) error {
+	if err := processValidate(&enum, true); err != nil {
+		return err
+	}
+
+	bs, err := processTemplate(enum)
+
+	if err != nil {
+		return err
+	}
+
+	if err := processWrite(enum, bs); err != nil {
+		return err
+	}
+
+	if enum.Proto != nil {
+		if err := writeFile(enum.Proto.OutputPath, enum.Overwrite, generateProto(enum)); err != nil {
+			return err
+		}
+	}
+
+	if enum.Schema != nil {
+		bs, err := generateSchema(enum)
 
 		if err != nil {
-			handleErr(err)
+			return err
 		}
 
-		if err := processWrite(enum, bs); err != nil {
-			handleErr(err)
+		if err := writeFile(enum.Schema.OutputPath, enum.Overwrite, bs); err != nil {
+			return err
+		}
+	}
+
+	if enum.GraphQL != nil {
+		if err := writeFile(enum.GraphQL.OutputPath, enum.Overwrite, generateGraphQL(enum)); err != nil {
+			return err
 		}
 	}
+
+	if hasFeature(enum, featureTests) {
+		bs, err := generateTests(enum)
+
+		if err != nil {
+			return err
+		}
+
+		if err := writeFile(testsOutputPath(enum), enum.Overwrite, bs); err != nil {
+			return err
+		}
+	}
+
+	return writeTemplates(enum)
 }
 
-func generateJsonSchema() string {
-	m := map[string]any{
-		"$schema": "http://json-schema.org/draft-07/schema",
-		"title":   "Bounded Infinity enumeration tool",
-		"type":    "object",
-		"version": "1.0.19",
-		"properties": map[string]any{
-			"type": map[string]any{
-				"type": "string",
-			},
-			"package": map[string]any{
-				"type": "string",
-			},
-			"output-path": map[string]any{
-				"type": "string",
-			},
-			"desc": map[string]any{
-				"type": "string",
-			},
-			"header": map[string]any{
-				"type": "string",
-			},
-			"header-from": map[string]any{
-				"type": "string",
-			},
-			"serialize": map[string]any{
-				"type": "object",
-				"properties": map[string]any{
-					"type": map[string]any{
-						"type": "string",
-						"enum": caser.ConverterCombinations(),
-					},
-					"value": map[string]any{
-						"type": "string",
-						"enum": caser.ConverterCombinations(),
-					},
-				},
-			},
-			"skip-format": map[string]any{
-				"type": "boolean",
-			},
-			"debug": map[string]any{
-				"type": "boolean",
-			},
-			"values": map[string]any{
-				"type": "array",
-				"items": map[string]any{
-					"type": "object",
-					"properties": map[string]any{
-						"name": map[string]any{
-							"type": "string",
-						},
-						"serialized": map[string]any{
-							"type": "string",
-						},
-					},
-				},
-			},
-		},
+// processValidate runs every check and defaulting step between loading the
+// config and generating code. Proto numbers assigned to values without one
+// are only written back to the config when persist is set.
+func processValidate(enum *enumer.EnumData, persist bool) error {
+	if err := processFeatures(enum); err != nil {
+		return err
+	}
+
+	if err := processGroups(enum); err != nil {
+		return err
 	}
 
-	bs, err := json.MarshalIndent(m, "", "    ")
+	if err := processTransitions(enum); err != nil {
+		return err
+	}
 
-	if err != nil {
-		panic(err)
+	if err := processMapsTo(enum); err != nil {
+		return err
+	}
+
+	if err := processOrdinal(enum); err != nil {
+		return err
+	}
+
+	if err := processProto(enum, persist); err != nil {
+		return err
 	}
 
-	return string(bs)
+	if err := processSchema(enum); err != nil {
+		return err
+	}
+
+	if err := processGraphQL(enum); err != nil {
+		return err
+	}
+
+	if err := processTemplates(enum); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func processJsonSchema(args argsData) error {
@@ -202,9 +263,26 @@ func processArgs(args *argsData) error {
 	flag.BoolVar(&args.SkipFormat, "skip-format", false, "Skip source formatting.")
 	flag.BoolVar(&args.Debug, "debug", false, "Enabled debugging.")
 	flag.StringVar(&args.VsCode, "vscode", "", "Path to project to configure the Visual Studio Code JSON Schema file.")
+	flag.StringVar(&args.SourcePath, "source", "", "The Go file with //enumer:enum types used instead of a config.")
+	flag.StringVar(&args.WatchPath, "watch", "", "The directory watched for .enum.yaml changes to regenerate.")
+	flag.DurationVar(&args.WatchInterval, "watch-interval", 500*time.Millisecond, "How often the watched directory is polled.")
 	flag.Parse()
 
-	if args.VsCode != "" {
+	if args.VsCode != "" || args.WatchPath != "" {
+		return nil
+	}
+
+	if args.SourcePath != "" {
+		if absPath, err := filepath.Abs(args.SourcePath); err != nil {
+			return err
+		} else {
+			args.SourcePath = absPath
+		}
+
+		if _, err := os.Stat(args.SourcePath); err != nil {
+			return fmt.Errorf("invalid source path %v: %w", args.SourcePath, err)
+		}
+
 		return nil
 	}
 
@@ -232,14 +310,44 @@ func processArgs(args *argsData) error {
 }
 
 func processEnum(args argsData, enum *enumer.EnumData) error {
-	if bs, err := os.ReadFile(args.InputPath); err == nil {
-		if err := yaml.Unmarshal(bs, &enum); err != nil {
-			return fmt.Errorf("can't parse config path %v : %w", args.InputPath, err)
-		}
-	} else {
+	return loadEnum(args, enum, nil)
+}
+
+// loadEnum loads the config at args.InputPath. The stack holds the configs
+// being loaded which extend or include it, to detect cycles.
+func loadEnum(args argsData, enum *enumer.EnumData, stack []string) error {
+	bs, err := os.ReadFile(args.InputPath)
+
+	if err != nil {
 		return fmt.Errorf("can't load config path %v : %w", args.InputPath, err)
 	}
 
+	return loadEnumBytes(args, bs, enum, stack)
+}
+
+// loadEnumBytes loads a config from bs as if it were read from
+// args.InputPath.
+func loadEnumBytes(args argsData, bs []byte, enum *enumer.EnumData, stack []string) error {
+	if err := processProject(args.InputPath, enum); err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(bs, &enum); err != nil {
+		return fmt.Errorf("can't parse config path %v : %w", args.InputPath, err)
+	}
+
+	inherited, err := processExtends(args, enum, stack)
+
+	if err != nil {
+		return err
+	}
+
+	return processDefaults(args, enum, inherited...)
+}
+
+// processDefaults fills in everything a config may leave out and normalizes
+// the values, which override the inherited values by name.
+func processDefaults(args argsData, enum *enumer.EnumData, inherited ...enumer.EnumValue) error {
 	if args.SkipFormat {
 		enum.SkipFormat = args.SkipFormat
 	}
@@ -274,33 +382,20 @@ func processEnum(args argsData, enum *enumer.EnumData) error {
 		enum.Struct = pluralize.NewClient().Plural(enum.Struct)
 	}
 
-	for i := 0; i < len(enum.Values); i++ {
-		value := enum.Values[i]
-		translate := func(s string) string {
-			for from, to := range mapper.MergeCopy(enum.Translate, value.Translate) {
-				s = stringer.Replace(s, to, from)
-			}
-			return s
-		}
+	loaded, err := processValuesFrom(enum)
 
-		switch {
-		case stringer.IsDefined(value.Name) && stringer.IsDefined(value.Serialized):
-			value.Name = translate(value.Name)
-			value.Name = langer.Go.MustIdentifier(value.Name)
-		case stringer.IsEmpty(value.Name) && stringer.IsDefined(value.Serialized):
-			value.Name = value.Serialized
-			value.Name = translate(value.Name)
-			value.Name = langer.Go.MustIdentifier(value.Name)
-		case stringer.IsDefined(value.Name) && stringer.IsEmpty(value.Serialized):
-			value.Serialized = value.Name
-			value.Serialized = langer.Json.MustIdentifier(value.Name)
-			value.Name = translate(value.Name)
-			value.Name = langer.Go.MustIdentifier(value.Name)
-		default:
-			return fmt.Errorf("invalid values[%v] name or serialized value", i)
-		}
+	if err != nil {
+		return err
+	}
+
+	rows, err := overrideValues(*enum, inherited, loaded, "values-from")
+
+	if err != nil {
+		return err
+	}
 
-		enum.Values[i] = value
+	if enum.Values, err = overrideValues(*enum, rows, enum.Values, "values"); err != nil {
+		return err
 	}
 
 	if enum.Header == "" && enum.HeaderFrom == "" {
@@ -333,6 +428,108 @@ func processEnum(args argsData, enum *enumer.EnumData) error {
 	return nil
 }
 
+// overrideValues normalizes values and adds them to the already normalized
+// base values. A value with the same name as a base value overrides only
+// the fields it sets, and the others are appended. Errors name a value by
+// its index in the list called label.
+func overrideValues(enum enumer.EnumData, base []enumer.EnumValue, values []enumer.EnumValue, label string) ([]enumer.EnumValue, error) {
+	result := append([]enumer.EnumValue{}, base...)
+
+	for i, value := range values {
+		normalized, err := normalizeValue(enum, value)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v[%v] %w", label, i, err)
+		}
+
+		overridden := false
+
+		for j := range base {
+			if result[j].Name == normalized.Name {
+				result[j] = overrideValue(result[j], value)
+				overridden = true
+				break
+			}
+		}
+
+		if !overridden {
+			result = append(result, normalized)
+		}
+	}
+
+	return result, nil
+}
+
+// overrideValue returns base with the fields value sets replaced. Attributes
+// are overridden one by one.
+func overrideValue(base enumer.EnumValue, value enumer.EnumValue) enumer.EnumValue {
+	if value.Serialized != "" {
+		base.Serialized = value.Serialized
+	}
+
+	if value.Desc != "" {
+		base.Desc = value.Desc
+	}
+
+	if value.ParseFrom != nil {
+		base.ParseFrom = value.ParseFrom
+	}
+
+	if value.ProtoNumber != 0 {
+		base.ProtoNumber = value.ProtoNumber
+	}
+
+	if value.Rank != nil {
+		base.Rank = value.Rank
+	}
+
+	if value.Groups != nil {
+		base.Groups = value.Groups
+	}
+
+	if value.Attributes != nil {
+		base.Attributes = mapper.MergeCopy(base.Attributes, value.Attributes)
+	}
+
+	return base
+}
+
+// normalizeValue fills in the name or serialized value a config value leaves
+// out, and converts the name to a Go identifier.
+func normalizeValue(enum enumer.EnumData, value enumer.EnumValue) (enumer.EnumValue, error) {
+	translate := func(s string) string {
+		for from, to := range mapper.MergeCopy(enum.Translate, value.Translate) {
+			s = stringer.Replace(s, to, from)
+		}
+		return s
+	}
+
+	switch {
+	case stringer.IsDefined(value.Name) && stringer.IsDefined(value.Serialized):
+	case stringer.IsEmpty(value.Name) && stringer.IsDefined(value.Serialized):
+		value.Name = value.Serialized
+	case stringer.IsDefined(value.Name) && stringer.IsEmpty(value.Serialized):
+		serialized, err := jsonIdentifier(value.Name)
+
+		if err != nil {
+			return value, fmt.Errorf("name: %w", err)
+		}
+
+		value.Serialized = serialized
+	default:
+		return value, errors.New("name or serialized value")
+	}
+
+	name, err := goIdentifier(translate(value.Name))
+
+	if err != nil {
+		return value, fmt.Errorf("name: %w", err)
+	}
+
+	value.Name = name
+	return value, nil
+}
+
 func box(text string) string {
 	lines := strings.Split(text, "\n")
 
@@ -347,9 +544,13 @@ func box(text string) string {
 }
 
 func processWrite(enum enumer.EnumData, bs []byte) error {
-	if pather.Paths.Exists(enum.OutputPath) {
-		if enum.Overwrite {
-			if _, err := pather.Paths.RemoveErr(enum.OutputPath); err != nil {
+	return writeFile(enum.OutputPath, enum.Overwrite, bs)
+}
+
+func writeFile(path string, overwrite bool, bs []byte) error {
+	if pather.Paths.Exists(path) {
+		if overwrite {
+			if _, err := pather.Paths.RemoveErr(path); err != nil {
 				return err
 			}
 		} else {
@@ -357,18 +558,18 @@ func processWrite(enum enumer.EnumData, bs []byte) error {
 		}
 	}
 
-	dir := pather.Paths.Dir(enum.OutputPath)
+	dir := pather.Paths.Dir(path)
 	err := os.MkdirAll(dir, FilePermissions)
 
 	if err != nil {
 		return err
 	}
 
-	if _, err := pather.Paths.RemoveErr(enum.OutputPath); err != nil {
+	if _, err := pather.Paths.RemoveErr(path); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(enum.OutputPath, bs, FilePermissions); err != nil {
+	if err := os.WriteFile(path, bs, FilePermissions); err != nil {
 		return err
 	}
 
@@ -387,12 +588,14 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 	///                             Type                             /
 	//////////////////////////////////////////////////////////////////
 
-	f.Comment(box("Type")).Line()
+	if !enum.SkipType {
+		f.Comment(box("Type")).Line()
 
-	if enum.Desc != "" {
-		f.Commentf("%s %s", enum.Type, utfer.RemoveNewlines(enum.Desc))
+		if enum.Desc != "" {
+			f.Commentf("%s %s", enum.Type, utfer.RemoveNewlines(enum.Desc))
+		}
+		f.Type().Id(enum.Type).String().Line()
 	}
-	f.Type().Id(enum.Type).String().Line()
 
 	f.Comment(box("Stringer implemenation")).Line()
 
@@ -400,57 +603,104 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 		Block(jen.Return(jen.String().Params(jen.Id("t")))).
 		Line()
 
-	f.Comment(box("JSON marshal/unmarshal implemenation")).Line()
+	if hasFeature(enum, featureJson) {
+		f.Comment(box("JSON marshal/unmarshal implemenation")).Line()
+
+		f.Func().Params(jen.Id("t").Id(enum.Type)).
+			Id("MarshalJSON").
+			Params().Params(jen.Index().Byte(), jen.Error()).
+			Block(jen.Return(
+				jen.Qual("encoding/json", "Marshal").Params(jen.String().Parens(jen.Id("t"))),
+			)).Line()
+
+		f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).
+			Id("UnmarshalJSON").
+			Params(jen.Id("data").Index().Byte()).Params(jen.Error()).
+			Block(
+				jen.Var().Id("s").String().Line(),
+
+				jen.If(
+					jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").
+						Params(jen.Id("data"), jen.Op("&").Id("s")),
+					jen.Err().Op("!=").Nil(),
+				).Block(
+					jen.Return(jen.Err()),
+				).Line(),
 
-	f.Func().Params(jen.Id("t").Id(enum.Type)).
-		Id("MarshalJSON").
-		Params().Params(jen.Index().Byte(), jen.Error()).
-		Block(jen.Return(
-			jen.Qual("encoding/json", "Marshal").Params(jen.String().Parens(jen.Id("t"))),
-		)).Line()
+				jen.Id("found").Op(",").Err().Op(":=").
+					Id(companionVar).Dot("Parse").Call(jen.Id("s")).
+					Line(),
 
-	f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).
-		Id("UnmarshalJSON").
-		Params(jen.Id("data").Index().Byte()).Params(jen.Error()).
-		Block(
-			jen.Var().Id("s").String().Line(),
+				jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
 
-			jen.If(
-				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").
-					Params(jen.Id("data"), jen.Op("&").Id("s")),
-				jen.Err().Op("!=").Nil(),
-			).Block(
-				jen.Return(jen.Err()),
-			).Line(),
+				jen.Op("*").Id("t").Op("=").Id("found"),
 
-			jen.Id("found").Op(",").Err().Op(":=").
-				Id(companionVar).Dot("Parse").Call(jen.Id("s")).
-				Line(),
+				jen.Return(jen.Nil()),
+			).Line()
+	}
 
-			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+	if hasFeature(enum, featureYaml) {
+		f.Comment(box("YAML marshal/unmarshal implemenation")).Line()
+
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id("MarshalYAML").
+			Params().Params(jen.Interface(), jen.Error()).
+			Block(
+				jen.Return(jen.String().Parens(jen.Id("t")).Op(",").Nil())).
+			Line()
+
+		f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("UnmarshalYAML").Params(
+			jen.Id("unmarshal").Func().Params(jen.Interface()).Error()).
+			Error().
+			Block(
+				jen.Var().Id("s").String().Line(),
+
+				jen.If(
+					jen.Err().Op(":=").Id("unmarshal").
+						Params(jen.Op("&").Id("s")),
+					jen.Err().Op("!=").Nil(),
+				).Block(
+					jen.Return(jen.Err()),
+				).Line(),
 
-			jen.Op("*").Id("t").Op("=").Id("found"),
+				jen.Id("found").Op(",").Err().Op(":=").
+					Id(companionVar).Dot("Parse").Call(jen.Id("s")).
+					Line(),
 
-			jen.Return(jen.Nil()),
-		).Line()
+				jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
 
-	f.Comment(box("YAML marshal/unmarshal implemenation")).Line()
+				jen.Op("*").Id("t").Op("=").Id("found"),
 
-	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("MarshalYAML").
-		Params().Params(jen.Interface(), jen.Error()).
-		Block(
-			jen.Return(jen.String().Parens(jen.Id("t")).Op(",").Nil())).
-		Line()
+				jen.Return(jen.Nil()),
+			).
+			Line()
+	}
 
-	f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("UnmarshalYAML").Params(
-		jen.Id("unmarshal").Func().Params(jen.Interface()).Error()).
-		Error().
-		Block(
+	if hasFeature(enum, featureXml) {
+		f.Comment(box("XML marshal/unmarshal implemenation")).Line()
+
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id("MarshalXML").Params(
+			jen.Id("e").Op("*").Qual("encoding/xml", "Encoder"),
+			jen.Id("start").Qual("encoding/xml", "StartElement"),
+		).Error().Block(
+			jen.Return(
+				jen.Id("e").Dot("EncodeElement").Params(
+					jen.String().Params(jen.Id("t")),
+					jen.Id("start"),
+				),
+			),
+		).Line()
+
+		f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("UnmarshalXML").Params(
+			jen.Id("d").Op("*").Qual("encoding/xml", "Decoder"),
+			jen.Id("start").Qual("encoding/xml", "StartElement"),
+		).Error().Block(
 			jen.Var().Id("s").String().Line(),
 
 			jen.If(
-				jen.Err().Op(":=").Id("unmarshal").
-					Params(jen.Op("&").Id("s")),
+				jen.Err().Op(":=").Id("d").Dot("DecodeElement").Params(
+					jen.Op("&").Id("s"),
+					jen.Op("&").Id("start"),
+				),
 				jen.Err().Op("!=").Nil(),
 			).Block(
 				jen.Return(jen.Err()),
@@ -465,96 +715,77 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 			jen.Op("*").Id("t").Op("=").Id("found"),
 
 			jen.Return(jen.Nil()),
-		).
-		Line()
+		).Line()
+	}
 
-	f.Comment(box("XML marshal/unmarshal implemenation")).Line()
+	if hasFeature(enum, featureSql) {
+		f.Comment(box("SQL marshal/unmarshal implemenation")).Line()
 
-	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("MarshalXML").Params(
-		jen.Id("e").Op("*").Qual("encoding/xml", "Encoder"),
-		jen.Id("start").Qual("encoding/xml", "StartElement"),
-	).Error().Block(
-		jen.Return(
-			jen.Id("e").Dot("EncodeElement").Params(
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Value").Params().Params(
+			jen.Qual("database/sql/driver", "Value"),
+			jen.Error(),
+		).Block(
+			jen.Return(
 				jen.String().Params(jen.Id("t")),
-				jen.Id("start"),
-			),
-		),
-	).Line()
-
-	f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("UnmarshalXML").Params(
-		jen.Id("d").Op("*").Qual("encoding/xml", "Decoder"),
-		jen.Id("start").Qual("encoding/xml", "StartElement"),
-	).Error().Block(
-		jen.Var().Id("s").String().Line(),
-
-		jen.If(
-			jen.Err().Op(":=").Id("d").Dot("DecodeElement").Params(
-				jen.Op("&").Id("s"),
-				jen.Op("&").Id("start"),
+				jen.Nil(),
 			),
-			jen.Err().Op("!=").Nil(),
-		).Block(
-			jen.Return(jen.Err()),
-		).Line(),
-
-		jen.Id("found").Op(",").Err().Op(":=").
-			Id(companionVar).Dot("Parse").Call(jen.Id("s")).
-			Line(),
+		).Line()
 
-		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+		f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("Scan").Params(
+			jen.Id("value").Interface(),
+		).Error().Block(
+			jen.If(
+				jen.Id("value").Op("==").Nil().Block(
+					jen.Return(jen.Id(companionVar).Dot("errf").Params(jen.Id("value"))),
+				),
+			).Line(),
 
-		jen.Op("*").Id("t").Op("=").Id("found"),
+			jen.Id("dv").Op(",").Err().Op(":=").Qual("database/sql/driver", "String").
+				Dot("ConvertValue").Params(jen.Id("value")).Line(),
 
-		jen.Return(jen.Nil()),
-	).Line()
-
-	f.Comment(box("SQL marshal/unmarshal implemenation")).Line()
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
 
-	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Value").Params().Params(
-		jen.Qual("database/sql/driver", "Value"),
-		jen.Error(),
-	).Block(
-		jen.Return(
-			jen.String().Params(jen.Id("t")),
-			jen.Nil(),
-		),
-	).Line()
+			jen.Id("s").Op(",").Id("ok").Op(":=").Id("dv").Assert(jen.String()).Line(),
 
-	f.Func().Params(jen.Id("t").Op("*").Id(enum.Type)).Id("Scan").Params(
-		jen.Id("value").Interface(),
-	).Error().Block(
-		jen.If(
-			jen.Id("value").Op("==").Nil().Block(
+			jen.If(jen.Op("!").Id("ok")).Block(
 				jen.Return(jen.Id(companionVar).Dot("errf").Params(jen.Id("value"))),
-			),
-		).Line(),
-
-		jen.Id("dv").Op(",").Err().Op(":=").Qual("database/sql/driver", "String").
-			Dot("ConvertValue").Params(jen.Id("value")).Line(),
+			).Line(),
 
-		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+			jen.Id("found").Op(",").Err().Op(":=").
+				Id(companionVar).Dot("Parse").Call(jen.Id("s")).
+				Line(),
 
-		jen.Id("s").Op(",").Id("ok").Op(":=").Id("dv").Assert(jen.String()).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
 
-		jen.If(jen.Op("!").Id("ok")).Block(
-			jen.Return(jen.Id(companionVar).Dot("errf").Params(jen.Id("value"))),
-		).Line(),
+			jen.Op("*").Id("t").Op("=").Id("found"),
 
-		jen.Id("found").Op(",").Err().Op(":=").
-			Id(companionVar).Dot("Parse").Call(jen.Id("s")).
-			Line(),
+			jen.Return(jen.Nil()),
+		).Line()
 
-		jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+	}
 
-		jen.Op("*").Id("t").Op("=").Id("found"),
+	templateProto(f, enum, companionVar, companionStruct)
+	templateSchema(f, enum, companionStruct)
+	templateGraphQL(f, enum, companionVar)
+	templateMatch(f, enum, companionVar)
+	templateOrdinal(f, enum, companionVar, companionStruct)
+	templateIndex(f, enum, companionVar)
+	templateSet(f, enum, companionVar)
+	templateMap(f, enum, companionVar)
+	templateIter(f, enum, companionStruct)
+	templateGroups(f, enum, companionVar, companionStruct)
+
+	if err := templateTransitions(f, enum, companionVar); err != nil {
+		return nil, err
+	}
 
-		jen.Return(jen.Nil()),
-	).Line()
+	if err := templateMapsTo(f, enum, companionVar, companionStruct); err != nil {
+		return nil, err
+	}
 
-	//////////////////////////////////////////////////////////////////
-	///                     Companion struct                         /
-	//////////////////////////////////////////////////////////////////
+	if err := templateAttributes(f, enum, companionVar); err != nil {
+		return nil, err
+	}
 
 	f.Comment(box("Companion struct")).Line()
 
@@ -657,32 +888,25 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 		jen.Return(jen.Id("found"), jen.Nil()),
 	).Line()
 
-	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Parse").Params(jen.Id("v").String()).Params(
-		jen.Id(enum.Type).Op(",").Error(),
-	).Block(
-		jen.Return(jen.Id("t").Dot("ParseFrom").Params(
-			jen.Id("v"),
-			jen.Id("t").Dot("Values").Params().Op("..."),
-		)),
-	).Line()
+	templateParse(f, enum, companionVar, companionStruct)
 
-	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("IsFrom").Params(
-		jen.Id("v").String(),
-		jen.Id("items").Op("...").Id(enum.Type),
-	).Bool().Block(
-		jen.Id("_").Op(",").Err().Op(":=").Id("t").Dot("ParseFrom").Params(
-			jen.Id("v"),
-			jen.Id("items").Op("..."),
-		),
-		jen.Return(jen.Err().Op("==").Nil()),
-	).Line()
+	if hasFeature(enum, featureIs) {
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id("IsFrom").Params(
+			jen.Id("v").String(),
+			jen.Id("items").Op("...").Id(enum.Type),
+		).Bool().Block(
+			jen.Id("_").Op(",").Err().Op(":=").Id("t").Dot("ParseFrom").Params(
+				jen.Id("v"),
+				jen.Id("items").Op("..."),
+			),
+			jen.Return(jen.Err().Op("==").Nil()),
+		).Line()
 
-	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Is").Params(jen.Id("v").String()).Bool().Block(
-		jen.Return(jen.Id("t").Dot("IsFrom").Params(
-			jen.Id("v"),
-			jen.Id("t").Dot("Values").Params().Op("..."),
-		)),
-	).Line()
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Is").Params(jen.Id("v").String()).Bool().Block(
+			jen.Id("_").Op(",").Err().Op(":=").Id("t").Dot("Parse").Params(jen.Id("v")),
+			jen.Return(jen.Err().Op("==").Nil()),
+		).Line()
+	}
 
 	f.Comment(box("Initialization")).Line()
 
@@ -691,10 +915,8 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 			for _, value := range enum.Values {
 				if _, ok := d[jen.Lit(value.Name)]; !ok {
 					d[jen.Id(companionVar).Dot(value.Name)] = jen.ValuesFunc(func(g2 *jen.Group) {
-						g2.Lit(value.Serialized)
-						g2.Lit(value.Name)
-						for _, from := range value.ParseFrom {
-							g2.Lit(from)
+						for _, matcher := range parseMatchers(enum, value) {
+							g2.Lit(matcher)
 						}
 					})
 				}
@@ -707,5 +929,5 @@ func processTemplate(enum enumer.EnumData) ([]byte, error) {
 	}).Line()
 
 	content := fmt.Sprintf("%#v", f)
-	return []byte(content), nil
+	return appendTemplates(enum, []byte(content))
 }
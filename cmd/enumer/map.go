@@ -0,0 +1,147 @@
+package main
+
+import (
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/langer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"github.com/dave/jennifer/jen"
+)
+
+func mapParam(value enumer.EnumValue) string {
+	param := stringer.ToLowerFirst(value.Name)
+
+	if langer.Go.IsKeyword(param) {
+		param += "_"
+	}
+
+	return param
+}
+
+// templateMap generates a generic map type backed by an array with one slot
+// per value, so every value always has an entry.
+func templateMap(f *jen.File, enum enumer.EnumData, companionVar string) {
+	if !hasFeature(enum, featureMap) {
+		return
+	}
+
+	mapType := enum.Type + "Map"
+	indexFn := indexFn(enum)
+	size := jen.Lit(len(enum.Values))
+	self := func() *jen.Statement { return jen.Id(mapType).Types(jen.Id("T")) }
+
+	f.Comment(box("Map implemenation")).Line()
+
+	f.Commentf("%s is a map with a value for every %s.", mapType, enum.Type)
+	f.Type().Id(mapType).Types(jen.Id("T").Any()).Struct(
+		jen.Id("values").Index(size).Id("T"),
+	).Line()
+
+	f.Commentf("New%s returns a map with a value for every %s.", mapType, enum.Type)
+	f.Func().Id("New" + mapType).Types(jen.Id("T").Any()).ParamsFunc(func(g *jen.Group) {
+		for _, value := range enum.Values {
+			g.Line().Id(mapParam(value)).Id("T")
+		}
+		g.Line()
+	}).Add(self()).Block(
+		jen.Return(self().Values(jen.Dict{
+			jen.Id("values"): jen.Index(size).Id("T").ValuesFunc(func(g *jen.Group) {
+				for _, value := range enum.Values {
+					g.Id(mapParam(value))
+				}
+			}),
+		})),
+	).Line()
+
+	f.Comment("Get returns the value for key, or the zero value if key is invalid.")
+	f.Func().Params(jen.Id("m").Add(self())).Id("Get").Params(jen.Id("key").Id(enum.Type)).Id("T").Block(
+		jen.Var().Id("zero").Id("T").Line(),
+		jen.If(jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("key")), jen.Id("i").Op(">=").Lit(0)).Block(
+			jen.Return(jen.Id("m").Dot("values").Index(jen.Id("i"))),
+		).Line(),
+		jen.Return(jen.Id("zero")),
+	).Line()
+
+	f.Comment("Set sets the value for key, ignoring invalid keys.")
+	f.Func().Params(jen.Id("m").Op("*").Add(self())).Id("Set").Params(
+		jen.Id("key").Id(enum.Type),
+		jen.Id("value").Id("T"),
+	).Block(
+		jen.If(jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("key")), jen.Id("i").Op(">=").Lit(0)).Block(
+			jen.Id("m").Dot("values").Index(jen.Id("i")).Op("=").Id("value"),
+		),
+	).Line()
+
+	f.Commentf("Range calls fn for each key and value in %s order until fn returns false.", companionVar)
+	f.Func().Params(jen.Id("m").Add(self())).Id("Range").Params(
+		jen.Id("fn").Func().Params(jen.Id(enum.Type), jen.Id("T")).Bool(),
+	).Block(
+		jen.For(jen.Id("i").Op(",").Id("key").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+			jen.If(jen.Op("!").Id("fn").Call(jen.Id("key"), jen.Id("m").Dot("values").Index(jen.Id("i")))).Block(
+				jen.Return(),
+			),
+		),
+	).Line()
+
+	if !hasFeature(enum, featureJson) {
+		return
+	}
+
+	f.Func().Params(jen.Id("m").Add(self())).Id("MarshalJSON").Params().Params(jen.Index().Byte(), jen.Error()).Block(
+		jen.Var().Id("buf").Qual("bytes", "Buffer"),
+		jen.Id("buf").Dot("WriteByte").Call(jen.LitRune('{')).Line(),
+
+		jen.For(jen.Id("i").Op(",").Id("key").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+			jen.If(jen.Id("i").Op(">").Lit(0)).Block(
+				jen.Id("buf").Dot("WriteByte").Call(jen.LitRune(',')),
+			).Line(),
+
+			jen.Id("k").Op(",").Err().Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id("key").Dot("String").Call()).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())).Line(),
+
+			jen.Id("v").Op(",").Err().Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id("m").Dot("values").Index(jen.Id("i"))).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())).Line(),
+
+			jen.Id("buf").Dot("Write").Call(jen.Id("k")),
+			jen.Id("buf").Dot("WriteByte").Call(jen.LitRune(':')),
+			jen.Id("buf").Dot("Write").Call(jen.Id("v")),
+		).Line(),
+
+		jen.Id("buf").Dot("WriteByte").Call(jen.LitRune('}')),
+		jen.Return(jen.Id("buf").Dot("Bytes").Call(), jen.Nil()),
+	).Line()
+
+	f.Func().Params(jen.Id("m").Op("*").Add(self())).Id("UnmarshalJSON").Params(jen.Id("data").Index().Byte()).Error().Block(
+		jen.Var().Id("raw").Map(jen.String()).Qual("encoding/json", "RawMessage").Line(),
+
+		jen.If(
+			jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Op("&").Id("raw")),
+			jen.Err().Op("!=").Nil(),
+		).Block(jen.Return(jen.Err())).Line(),
+
+		jen.Var().Id("parsed").Add(self()),
+		jen.Var().Id("seen").Index(size).Bool().Line(),
+
+		jen.For(jen.Id("k").Op(",").Id("v").Op(":=").Range().Id("raw")).Block(
+			jen.Id("key").Op(",").Err().Op(":=").Id(companionVar).Dot("Parse").Call(jen.Id("k")).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+
+			jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("key")).Line(),
+
+			jen.If(
+				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("v"), jen.Op("&").Id("parsed").Dot("values").Index(jen.Id("i"))),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Err())).Line(),
+
+			jen.Id("seen").Index(jen.Id("i")).Op("=").True(),
+		).Line(),
+
+		jen.For(jen.Id("i").Op(",").Id("key").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+			jen.If(jen.Op("!").Id("seen").Index(jen.Id("i"))).Block(
+				jen.Return(jen.Qual("fmt", "Errorf").Call(jen.Lit("%w: missing %v"), jen.Id(companionVar).Dot("Err"), jen.Id("key"))),
+			),
+		).Line(),
+
+		jen.Op("*").Id("m").Op("=").Id("parsed"),
+		jen.Return(jen.Nil()),
+	).Line()
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+	"github.com/gertd/go-pluralize"
+)
+
+type enumMapping struct {
+	Target      enumer.EnumData
+	ImportPath  string
+	Targets     map[string]string
+	Sources     map[string]string
+	CompanionOf string
+}
+
+// qual returns a reference to name in the target enum's package.
+func (t enumMapping) qual(name string) *jen.Statement {
+	if t.ImportPath == "" {
+		return jen.Id(name)
+	}
+
+	return jen.Qual(t.ImportPath, name)
+}
+
+// enumMappings loads the configs in the maps-to section and resolves each
+// value to the Go name of its target value. The reverse direction maps each
+// target value to the first value, in values order, which maps to it.
+func enumMappings(enum enumer.EnumData) ([]enumMapping, error) {
+	var mappings []enumMapping
+
+	for i, mapsTo := range enum.MapsTo {
+		if mapsTo.Config == "" {
+			return nil, fmt.Errorf("invalid maps-to[%v]: missing config", i)
+		}
+
+		path := mapsTo.Config
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(enum.InputPath), path)
+		}
+
+		var target enumer.EnumData
+
+		if err := processEnum(argsData{InputPath: path}, &target); err != nil {
+			return nil, fmt.Errorf("invalid maps-to[%v]: %w", i, err)
+		}
+
+		mapping := enumMapping{
+			Target:      target,
+			ImportPath:  mapsTo.Import,
+			Targets:     map[string]string{},
+			Sources:     map[string]string{},
+			CompanionOf: pluralize.NewClient().Plural(target.Type),
+		}
+
+		if mapping.ImportPath == "" {
+			targetDir, err := filepath.Abs(filepath.Dir(target.OutputPath))
+
+			if err != nil {
+				return nil, err
+			}
+
+			dir, err := filepath.Abs(filepath.Dir(enum.OutputPath))
+
+			if err != nil {
+				return nil, err
+			}
+
+			if targetDir != dir {
+				importPath, err := packageImportPath(targetDir)
+
+				if err != nil {
+					return nil, err
+				}
+
+				if importPath == "" {
+					return nil, fmt.Errorf("invalid maps-to[%v]: can't find the import path of %v, set import", i, targetDir)
+				}
+
+				mapping.ImportPath = importPath
+			}
+		}
+
+		if mapping.ImportPath == "" && target.Type == enum.Type {
+			return nil, fmt.Errorf("invalid maps-to[%v]: can't map %v to itself", i, enum.Type)
+		}
+
+		for from, to := range mapsTo.Values {
+			fromValue, err := findValue(enum, from)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid maps-to[%v]: %w in %v", i, err, enum.Type)
+			}
+
+			toValue, err := findValue(target, to)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid maps-to[%v] %v: %w in %v", i, from, err, target.Type)
+			}
+
+			mapping.Targets[fromValue.Name] = toValue.Name
+		}
+
+		var fallback string
+
+		if mapsTo.Fallback != "" {
+			value, err := findValue(target, mapsTo.Fallback)
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid maps-to[%v] fallback: %w in %v", i, err, target.Type)
+			}
+
+			fallback = value.Name
+		}
+
+		for _, value := range enum.Values {
+			if _, ok := mapping.Targets[value.Name]; ok {
+				continue
+			}
+
+			if fallback == "" {
+				return nil, fmt.Errorf("invalid maps-to[%v] %v: not mapped to a %v value and no fallback", i, value.Name, target.Type)
+			}
+
+			mapping.Targets[value.Name] = fallback
+		}
+
+		for _, value := range enum.Values {
+			to := mapping.Targets[value.Name]
+
+			if _, ok := mapping.Sources[to]; !ok {
+				mapping.Sources[to] = value.Name
+			}
+		}
+
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+func processMapsTo(enum *enumer.EnumData) error {
+	_, err := enumMappings(*enum)
+	return err
+}
+
+func templateMapsTo(f *jen.File, enum enumer.EnumData, companionVar, companionStruct string) error {
+	if len(enum.MapsTo) == 0 {
+		return nil
+	}
+
+	mappings, err := enumMappings(enum)
+
+	if err != nil {
+		return err
+	}
+
+	f.Comment(box("Mappings")).Line()
+
+	for _, mapping := range mappings {
+		target := mapping.Target
+
+		if mapping.ImportPath != "" {
+			f.ImportName(mapping.ImportPath, target.Package)
+		}
+
+		f.Commentf("To%s converts t to the %s it maps to.", target.Type, target.Type)
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id("To" + target.Type).Params().Add(mapping.qual(target.Type)).Block(
+			jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+				for _, value := range enum.Values {
+					g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+						jen.Return(mapping.qual(mapping.CompanionOf).Dot(mapping.Targets[value.Name])),
+					)
+				}
+
+				g.Default().Block(jen.Return(mapping.qual(mapping.CompanionOf).Dot("Invalid")))
+			}),
+		).Line()
+
+		f.Commentf("From%s converts v to the first %s which maps to it.", target.Type, enum.Type)
+		f.Func().Params(jen.Id("t").Id(companionStruct)).Id("From"+target.Type).Params(
+			jen.Id("v").Add(mapping.qual(target.Type)),
+		).Params(
+			jen.Id(enum.Type),
+			jen.Error(),
+		).Block(
+			jen.Switch(jen.Id("v")).BlockFunc(func(g *jen.Group) {
+				for _, value := range target.Values {
+					from, ok := mapping.Sources[value.Name]
+
+					if !ok {
+						continue
+					}
+
+					g.Case(mapping.qual(mapping.CompanionOf).Dot(value.Name)).Block(
+						jen.Return(jen.Id("t").Dot(from), jen.Nil()),
+					)
+				}
+
+				g.Default().Block(
+					jen.Id("list").Op(":=").Qual("strings", "Join").Params(
+						jen.Id("t").Dot("ToStrings").Call(jen.Id("t").Dot("Values").Call().Op("...")),
+						jen.Lit(","),
+					),
+					jen.Return(jen.Id("t").Dot("Invalid"), jen.Id("t").Dot("errf").Params(jen.Id("v"), jen.Id("list"))),
+				)
+			}),
+		).Line()
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+func matchParam(value enumer.EnumValue) string {
+	return "on" + value.Name
+}
+
+// templateMatch generates exhaustive Switch and Match helpers. Every value
+// is a required parameter, so adding a value to the config breaks each call
+// site that doesn't handle it.
+func templateMatch(f *jen.File, enum enumer.EnumData, companionVar string) {
+	if !hasFeature(enum, featureMatch) {
+		return
+	}
+
+	f.Comment(box("Exhaustive matching")).Line()
+
+	f.Commentf("Switch calls the function matching t, or onInvalid if t isn't a %s value.", enum.Type)
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Switch").ParamsFunc(func(g *jen.Group) {
+		for _, value := range enum.Values {
+			g.Line().Id(matchParam(value)).Func().Params()
+		}
+		g.Line().Id("onInvalid").Func().Params(jen.Id(enum.Type))
+		g.Line()
+	}).Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+					jen.Id(matchParam(value)).Call(),
+				)
+			}
+
+			g.Default().Block(
+				jen.Id("onInvalid").Call(jen.Id("t")),
+			)
+		}),
+	).Line()
+
+	f.Commentf("Match%s returns the result of the function matching t, or of onInvalid", enum.Type)
+	f.Commentf("if t isn't a %s value.", enum.Type)
+	f.Func().Id("Match" + enum.Type).Types(jen.Id("R").Any()).ParamsFunc(func(g *jen.Group) {
+		g.Line().Id("t").Id(enum.Type)
+		for _, value := range enum.Values {
+			g.Line().Id(matchParam(value)).Func().Params().Id("R")
+		}
+		g.Line().Id("onInvalid").Func().Params(jen.Id(enum.Type)).Id("R")
+		g.Line()
+	}).Id("R").Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+					jen.Return(jen.Id(matchParam(value)).Call()),
+				)
+			}
+
+			g.Default().Block(
+				jen.Return(jen.Id("onInvalid").Call(jen.Id("t"))),
+			)
+		}),
+	).Line()
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+// processOrdinal validates the explicit ranks. Values without a rank are
+// ranked after the explicit ones, in the order of the values list.
+func processOrdinal(enum *enumer.EnumData) error {
+	if !hasFeature(*enum, featureOrdinal) {
+		return nil
+	}
+
+	used := map[int]string{}
+
+	for i, value := range enum.Values {
+		if value.Rank == nil {
+			continue
+		}
+
+		if other, ok := used[*value.Rank]; ok {
+			return fmt.Errorf("invalid values[%v] rank %v: already used by %v", i, *value.Rank, other)
+		}
+
+		used[*value.Rank] = value.Name
+	}
+
+	return nil
+}
+
+// valueRanks returns the rank of each value. Values without a rank follow
+// the highest explicit rank, in the order of the values list.
+func valueRanks(values []enumer.EnumValue) []int {
+	next := 0
+
+	for _, value := range values {
+		if value.Rank != nil && *value.Rank >= next {
+			next = *value.Rank + 1
+		}
+	}
+
+	ranks := make([]int, len(values))
+
+	for i, value := range values {
+		if value.Rank != nil {
+			ranks[i] = *value.Rank
+		} else {
+			ranks[i] = next
+			next++
+		}
+	}
+
+	return ranks
+}
+
+// rankedValues returns the values sorted by rank.
+func rankedValues(enum enumer.EnumData) []enumer.EnumValue {
+	type ranked struct {
+		rank  int
+		value enumer.EnumValue
+	}
+
+	var items []ranked
+	ranks := valueRanks(enum.Values)
+
+	for i, value := range enum.Values {
+		items = append(items, ranked{rank: ranks[i], value: value})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].rank < items[j].rank
+	})
+
+	var values []enumer.EnumValue
+
+	for _, item := range items {
+		values = append(values, item.value)
+	}
+
+	return values
+}
+
+func templateOrdinal(f *jen.File, enum enumer.EnumData, companionVar, companionStruct string) {
+	if !hasFeature(enum, featureOrdinal) || len(enum.Values) == 0 {
+		return
+	}
+
+	values := rankedValues(enum)
+
+	f.Comment(box("Ordinal implemenation")).Line()
+
+	f.Commentf("Ordinal returns the position of t in the %s ordering, or -1 if t isn't a %s value.", enum.Type, enum.Type)
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Ordinal").Params().Int().Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for i, value := range values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(jen.Return(jen.Lit(i)))
+			}
+
+			g.Default().Block(jen.Return(jen.Lit(-1)))
+		}),
+	).Line()
+
+	f.Comment("Compare returns -1, 0 or +1 depending on whether t is ordered before, the same as or after other.")
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Compare").Params(jen.Id("other").Id(enum.Type)).Int().Block(
+		jen.Id("a").Op(",").Id("b").Op(":=").Id("t").Dot("Ordinal").Call().Op(",").Id("other").Dot("Ordinal").Call().Line(),
+
+		jen.Switch().Block(
+			jen.Case(jen.Id("a").Op("<").Id("b")).Block(jen.Return(jen.Lit(-1))),
+			jen.Case(jen.Id("a").Op(">").Id("b")).Block(jen.Return(jen.Lit(1))),
+			jen.Default().Block(jen.Return(jen.Lit(0))),
+		),
+	).Line()
+
+	f.Comment("Less reports whether t is ordered before other.")
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Less").Params(jen.Id("other").Id(enum.Type)).Bool().Block(
+		jen.Return(jen.Id("t").Dot("Ordinal").Call().Op("<").Id("other").Dot("Ordinal").Call()),
+	).Line()
+
+	navigate := func(name string, doc string, next func(i int) int) {
+		f.Comment(doc)
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id(name).Params().Id(enum.Type).Block(
+			jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+				for i, value := range values {
+					j := next(i)
+
+					if j < 0 || j >= len(values) {
+						continue
+					}
+
+					g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+						jen.Return(jen.Id(companionVar).Dot(values[j].Name)),
+					)
+				}
+
+				g.Default().Block(jen.Return(jen.Id(companionVar).Dot("Invalid")))
+			}),
+		).Line()
+	}
+
+	navigate("Next", "Next returns the value ordered after t, or Invalid if t is the last value.",
+		func(i int) int { return i + 1 })
+	navigate("Prev", "Prev returns the value ordered before t, or Invalid if t is the first value.",
+		func(i int) int { return i - 1 })
+
+	f.Comment("Min returns the first value in the ordering.")
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Min").Params().Id(enum.Type).Block(
+		jen.Return(jen.Id(companionVar).Dot(values[0].Name)),
+	).Line()
+
+	f.Comment("Max returns the last value in the ordering.")
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Max").Params().Id(enum.Type).Block(
+		jen.Return(jen.Id(companionVar).Dot(values[len(values)-1].Name)),
+	).Line()
+
+	f.Comment("FromOrdinal returns the value at position v in the ordering.")
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("FromOrdinal").Params(jen.Id("v").Int()).Params(
+		jen.Id(enum.Type),
+		jen.Error(),
+	).Block(
+		jen.Switch(jen.Id("v")).BlockFunc(func(g *jen.Group) {
+			for i, value := range values {
+				g.Case(jen.Lit(i)).Block(jen.Return(jen.Id(companionVar).Dot(value.Name), jen.Nil()))
+			}
+
+			g.Default().Block(
+				jen.Return(
+					jen.Id("t").Dot("Invalid"),
+					jen.Id("t").Dot("errf").Params(
+						jen.Id("v"),
+						jen.Qual("strings", "Join").Params(
+							jen.Id("t").Dot("ToStrings").Call(jen.Id("t").Dot("Values").Call().Op("...")),
+							jen.Lit(","),
+						),
+					),
+				),
+			)
+		}),
+	).Line()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processOrdinal(t *testing.T) {
+	rank := func(i int) *int { return &i }
+
+	testCases := []struct {
+		name     string
+		features []string
+		values   []enumer.EnumValue
+		expected []string
+		err      string
+	}{
+		{
+			name:     "list order",
+			features: []string{featureOrdinal},
+			values:   []enumer.EnumValue{{Name: "A"}, {Name: "B"}},
+			expected: []string{"A", "B"},
+		},
+		{
+			name:     "implicit after explicit",
+			features: []string{featureOrdinal},
+			values:   []enumer.EnumValue{{Name: "A"}, {Name: "B", Rank: rank(1)}, {Name: "C"}, {Name: "D", Rank: rank(0)}},
+			expected: []string{"D", "B", "A", "C"},
+		},
+		{
+			name:     "explicit rank of an implicit position",
+			features: []string{featureOrdinal},
+			values:   []enumer.EnumValue{{Name: "A", Rank: rank(1)}, {Name: "B"}},
+			expected: []string{"A", "B"},
+		},
+		{
+			name:     "duplicate rank",
+			features: []string{featureOrdinal},
+			values:   []enumer.EnumValue{{Name: "A", Rank: rank(1)}, {Name: "B", Rank: rank(1)}},
+			err:      "invalid values[1] rank 1: already used by A",
+		},
+		{
+			name:     "ordinal not enabled",
+			features: []string{featureJson},
+			values:   []enumer.EnumValue{{Name: "A", Rank: rank(1)}, {Name: "B", Rank: rank(1)}},
+			expected: []string{"A", "B"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			enum := enumer.EnumData{Features: tc.features, Values: tc.values}
+			err := processOrdinal(&enum)
+
+			if tc.err != "" {
+				assert.ErrorContains(tt, err, tc.err)
+				return
+			}
+
+			assert.Nil(tt, err)
+
+			var actual []string
+
+			for _, value := range rankedValues(enum) {
+				actual = append(actual, value.Name)
+			}
+
+			assert.Equal(tt, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+// parseMatchers returns the strings which parse to value.
+func parseMatchers(enum enumer.EnumData, value enumer.EnumValue) []string {
+	matchers := []string{value.Serialized, value.Name}
+	matchers = append(matchers, value.ParseFrom...)
+
+	if enum.GraphQL != nil {
+		matchers = append(matchers, graphqlIdentifier(value.Serialized))
+	}
+
+	return matchers
+}
+
+// templateParse generates Parse as a switch on the input so it doesn't
+// allocate. When a string matches more than one value the first value wins,
+// the same as ParseFrom over Values.
+func templateParse(f *jen.File, enum enumer.EnumData, companionVar, companionStruct string) {
+	var serialized []string
+
+	for _, value := range enum.Values {
+		serialized = append(serialized, value.Serialized)
+	}
+
+	seen := map[string]bool{}
+
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Parse").Params(jen.Id("v").String()).Params(
+		jen.Id(enum.Type).Op(",").Error(),
+	).Block(
+		jen.Switch(jen.Id("v")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				var matchers []string
+
+				for _, matcher := range parseMatchers(enum, value) {
+					if !seen[matcher] {
+						seen[matcher] = true
+						matchers = append(matchers, matcher)
+					}
+				}
+
+				if len(matchers) == 0 {
+					continue
+				}
+
+				g.CaseFunc(func(g *jen.Group) {
+					for _, matcher := range matchers {
+						g.Lit(matcher)
+					}
+				}).Block(jen.Return(jen.Id(companionVar).Dot(value.Name), jen.Nil()))
+			}
+
+			g.Default().Block(
+				jen.Return(
+					jen.Id("t").Dot("Invalid"),
+					jen.Id("t").Dot("errf").Params(jen.Id("v"), jen.Lit(strings.Join(serialized, ","))),
+				),
+			)
+		}),
+	).Line()
+}
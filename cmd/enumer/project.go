@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"gopkg.in/yaml.v2"
+)
+
+const projectFileName = "enumer.yaml"
+
+// findProject returns the nearest project file found by walking up from dir
+// to the module root, the directory with the go.mod, so files outside the
+// module never change its output. Outside a module only dir is searched.
+func findProject(dir string) (string, bool) {
+	root := dir
+
+	if goMod, ok := findGoMod(dir); ok {
+		root = filepath.Dir(goMod)
+	}
+
+	for {
+		path := filepath.Join(dir, projectFileName)
+
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+
+		if dir == root || parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// projectLayers returns the project settings which apply to the config at
+// path, as YAML documents to decode before the config itself. The global
+// translate map comes first, then the defaults, then the directory overrides
+// from the least to the most specific.
+func projectLayers(path string) ([][]byte, error) {
+	projectPath, ok := findProject(filepath.Dir(path))
+
+	if !ok {
+		return nil, nil
+	}
+
+	bs, err := os.ReadFile(projectPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't load project path %v : %w", projectPath, err)
+	}
+
+	var project enumer.EnumProject
+
+	if err := yaml.Unmarshal(bs, &project); err != nil {
+		return nil, fmt.Errorf("can't parse project path %v : %w", projectPath, err)
+	}
+
+	var layers []map[string]any
+
+	if len(project.Translate) > 0 {
+		layers = append(layers, map[string]any{"translate": project.Translate})
+	}
+
+	if len(project.Defaults) > 0 {
+		layers = append(layers, project.Defaults)
+	}
+
+	var dirs []string
+	projectDir := filepath.Dir(projectPath)
+
+	for dir := range project.Directories {
+		rel, err := filepath.Rel(filepath.Join(projectDir, dir), filepath.Dir(path))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if rel == "." || !strings.HasPrefix(rel, "..") {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		return len(filepath.Clean(dirs[i])) < len(filepath.Clean(dirs[j]))
+	})
+
+	for _, dir := range dirs {
+		layers = append(layers, project.Directories[dir])
+	}
+
+	var results [][]byte
+
+	for _, layer := range layers {
+		bs, err := yaml.Marshal(layer)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid project path %v : %w", projectPath, err)
+		}
+
+		results = append(results, bs)
+	}
+
+	return results, nil
+}
+
+// processProject decodes the project settings which apply to the config at
+// path into enum.
+func processProject(path string, enum *enumer.EnumData) error {
+	layers, err := projectLayers(path)
+
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range layers {
+		if err := yaml.Unmarshal(layer, enum); err != nil {
+			return fmt.Errorf("invalid project settings for %v : %w", path, err)
+		}
+	}
+
+	return nil
+}
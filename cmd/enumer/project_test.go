@@ -0,0 +1,53 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findProject(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, ok := findGoMod(dir); ok {
+		t.Skip("temporary directory is inside a module")
+	}
+
+	writeTestFiles(t, dir, map[string]string{
+		"enumer.yaml":                "defaults:\n    package: outside\n",
+		"mod/go.mod":                 "module example.com/mod\n",
+		"mod/a/b/color.enum.yaml":    "values:\n    - name: Red\n",
+		"mod/c/enumer.yaml":          "defaults:\n    package: inside\n",
+		"mod/c/d/shape.enum.yaml":    "values:\n    - name: Square\n",
+		"loose/e/size.enum.yaml":     "values:\n    - name: Small\n",
+		"loose/f/enumer.yaml":        "defaults:\n    package: loose\n",
+		"loose/f/weight.enum.yaml":   "values:\n    - name: Light\n",
+		"loose/f/g/height.enum.yaml": "values:\n    - name: Tall\n",
+	})
+
+	testCases := []struct {
+		name     string
+		dir      string
+		expected string
+	}{
+		{name: "stops at the module root", dir: "mod/a/b", expected: ""},
+		{name: "inside the module", dir: "mod/c/d", expected: "mod/c/enumer.yaml"},
+		{name: "outside a module", dir: "loose/e", expected: ""},
+		{name: "same directory outside a module", dir: "loose/f", expected: "loose/f/enumer.yaml"},
+		{name: "parent outside a module", dir: "loose/f/g", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			actual, ok := findProject(filepath.Join(dir, tc.dir))
+
+			if tc.expected == "" {
+				assert.False(tt, ok, actual)
+			} else {
+				assert.True(tt, ok)
+				assert.Equal(tt, filepath.Join(dir, tc.expected), actual)
+			}
+		})
+	}
+}
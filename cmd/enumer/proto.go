@@ -0,0 +1,406 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+	"github.com/boundedinfinity/go-commoner/idiomatic/pather"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+	"github.com/dave/jennifer/jen"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func processProto(enum *enumer.EnumData, persist bool) error {
+	if enum.Proto == nil {
+		return nil
+	}
+
+	if enum.Proto.Name == "" {
+		enum.Proto.Name = enum.Type
+	}
+
+	if enum.Proto.Package == "" {
+		enum.Proto.Package = stringer.Replace(enum.Package, "_", "-", " ")
+	}
+
+	if enum.Proto.OutputPath == "" {
+		name := caser.PascalToSnakeLower(enum.Proto.Name) + ".proto"
+		enum.Proto.OutputPath = pather.Join(pather.Paths.Dir(enum.OutputPath), name)
+	}
+
+	reserved := map[int]bool{}
+	used := map[int]string{}
+	next := 1
+
+	for _, number := range enum.Proto.Reserved {
+		if number <= 0 {
+			return fmt.Errorf("invalid proto reserved number %v: must be greater than 0", number)
+		}
+
+		reserved[number] = true
+
+		if number >= next {
+			next = number + 1
+		}
+	}
+
+	for i, value := range enum.Values {
+		if value.ProtoNumber == 0 {
+			continue
+		}
+
+		if value.ProtoNumber < 0 {
+			return fmt.Errorf("invalid values[%v] proto number %v: must be greater than 0", i, value.ProtoNumber)
+		}
+
+		if reserved[value.ProtoNumber] {
+			return fmt.Errorf("invalid values[%v] proto number %v: number is reserved", i, value.ProtoNumber)
+		}
+
+		if other, ok := used[value.ProtoNumber]; ok {
+			return fmt.Errorf("invalid values[%v] proto number %v: already used by %v", i, value.ProtoNumber, other)
+		}
+
+		used[value.ProtoNumber] = value.Name
+
+		if value.ProtoNumber >= next {
+			next = value.ProtoNumber + 1
+		}
+	}
+
+	// Numbers of removed values are reserved, so a later value never reuses
+	// a number a client may still send.
+	var retired []int
+
+	for _, number := range protoHistory(*enum) {
+		if _, ok := used[number]; ok || reserved[number] {
+			continue
+		}
+
+		reserved[number] = true
+		retired = append(retired, number)
+
+		if number >= next {
+			next = number + 1
+		}
+	}
+
+	sort.Ints(retired)
+	enum.Proto.Reserved = append(enum.Proto.Reserved, retired...)
+
+	assigned := map[int]int{}
+
+	for i := range enum.Values {
+		if enum.Values[i].ProtoNumber != 0 {
+			continue
+		}
+
+		enum.Values[i].ProtoNumber = next
+		assigned[i] = next
+		next++
+	}
+
+	if (len(assigned) == 0 && len(retired) == 0) || !persist {
+		return nil
+	}
+
+	if len(assigned) > 0 && (enum.Extends != "" || len(enum.Include) > 0 || enum.ValuesFrom != nil) {
+		return fmt.Errorf("invalid values: proto numbers must be set in the config when values are inherited")
+	}
+
+	if err := persistProtoNumbers(enum.InputPath, assigned, retired); err != nil {
+		return fmt.Errorf("can't persist proto numbers to %v: %w", enum.InputPath, err)
+	}
+
+	return nil
+}
+
+var protoHistoryNumber = regexp.MustCompile(`^\s*(?:[A-Z0-9_]+\s*=\s*(\d+)|reserved\s+([\d,\s]+));`)
+
+// protoHistory returns the numbers used by previous versions of the enum,
+// as recorded in the previously generated .proto file and the lock file
+// written by the compat command.
+func protoHistory(enum enumer.EnumData) []int {
+	var numbers []int
+
+	if bs, err := os.ReadFile(enum.Proto.OutputPath); err == nil {
+		for _, line := range strings.Split(string(bs), "\n") {
+			match := protoHistoryNumber.FindStringSubmatch(line)
+
+			if match == nil {
+				continue
+			}
+
+			for _, field := range strings.FieldsFunc(match[1]+","+match[2], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				if number, err := strconv.Atoi(field); err == nil && number > 0 {
+					numbers = append(numbers, number)
+				}
+			}
+		}
+	}
+
+	if lock, err := loadCompat(compatLockPath(enum.InputPath)); err == nil {
+		for _, value := range lock.Values {
+			if value.ProtoNumber > 0 {
+				numbers = append(numbers, value.ProtoNumber)
+			}
+		}
+	}
+
+	return numbers
+}
+
+// persistProtoNumbers writes newly assigned numbers, and the numbers of
+// removed values, back into the config so they stay stable between generator
+// runs. Only the changed lines are touched, so the config keeps its layout
+// and comments.
+func persistProtoNumbers(path string, assigned map[int]int, retired []int) error {
+	bs, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	var doc yamlv3.Node
+
+	if err := yamlv3.Unmarshal(bs, &doc); err != nil {
+		return err
+	}
+
+	if len(doc.Content) == 0 {
+		return fmt.Errorf("empty config")
+	}
+
+	root := doc.Content[0]
+	var edits []yamlEdit
+
+	if len(assigned) > 0 {
+		values := yamlNodeValue(root, "values")
+
+		if values == nil || values.Kind != yamlv3.SequenceNode {
+			return fmt.Errorf("missing values")
+		}
+
+		for i, number := range assigned {
+			if i >= len(values.Content) {
+				return fmt.Errorf("missing values[%v]", i)
+			}
+
+			item := values.Content[i]
+
+			if existing := yamlNodeValue(item, "proto-number"); existing != nil {
+				edits = append(edits, yamlEdit{
+					Line:   existing.Line,
+					Column: existing.Column,
+					Length: len(existing.Value),
+					Text:   fmt.Sprint(number),
+				})
+				continue
+			}
+
+			edit, err := yamlAddKey(bs, item, "proto-number", fmt.Sprint(number))
+
+			if err != nil {
+				return fmt.Errorf("values[%v]: %w", i, err)
+			}
+
+			edits = append(edits, edit)
+		}
+	}
+
+	if len(retired) > 0 {
+		edit, err := persistProtoReserved(bs, root, retired)
+
+		if err != nil {
+			return err
+		}
+
+		edits = append(edits, edit)
+	}
+
+	return os.WriteFile(path, applyYamlEdits(bs, edits), FilePermissions)
+}
+
+// persistProtoReserved returns the edit which appends numbers to the proto
+// reserved list.
+func persistProtoReserved(bs []byte, root *yamlv3.Node, numbers []int) (yamlEdit, error) {
+	var ss []string
+
+	for _, number := range numbers {
+		ss = append(ss, fmt.Sprint(number))
+	}
+
+	proto := yamlNodeValue(root, "proto")
+
+	if proto == nil {
+		return yamlEdit{}, fmt.Errorf("missing proto")
+	}
+
+	reserved := yamlNodeValue(proto, "reserved")
+
+	if reserved == nil {
+		return yamlAddKey(bs, proto, "reserved", "", ss...)
+	}
+
+	if reserved.Kind != yamlv3.SequenceNode {
+		return yamlEdit{}, fmt.Errorf("proto reserved must be a list")
+	}
+
+	if reserved.Style&yamlv3.FlowStyle != 0 {
+		if len(reserved.Content) == 0 {
+			return yamlEdit{Line: reserved.Line, Column: reserved.Column + 1, Text: strings.Join(ss, ", ")}, nil
+		}
+
+		last := reserved.Content[len(reserved.Content)-1]
+		return yamlEdit{Line: last.Line, Column: last.Column + len(last.Value), Text: ", " + strings.Join(ss, ", ")}, nil
+	}
+
+	// Block items repeat the indentation and dash of the last item.
+	last := reserved.Content[len(reserved.Content)-1]
+	prefix := string([]rune(strings.Split(string(bs), "\n")[last.Line-1])[:last.Column-1])
+	var lines []string
+
+	for _, s := range ss {
+		lines = append(lines, prefix+s)
+	}
+
+	return yamlEdit{Line: last.Line + 1, Text: strings.Join(lines, "\n")}, nil
+}
+
+func protoValueName(enum enumer.EnumData, name string) string {
+	return caser.PascalToSnakeUpper(enum.Proto.Name) + "_" + caser.PascalToSnakeUpper(name)
+}
+
+func generateProto(enum enumer.EnumData) []byte {
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	for _, line := range enum.HeaderLines {
+		writeln(stringer.TrimSpace("// " + line))
+	}
+
+	writeln("")
+	writeln(`syntax = "proto3";`)
+	writeln("")
+	writeln("package %s;", enum.Proto.Package)
+
+	if enum.Proto.GoPackage != "" {
+		writeln("")
+		writeln(`option go_package = "%s";`, enum.Proto.GoPackage)
+	}
+
+	writeln("")
+
+	if enum.Desc != "" {
+		writeln("// %s %s", enum.Proto.Name, utfer.RemoveNewlines(enum.Desc))
+	}
+
+	writeln("enum %s {", enum.Proto.Name)
+	writeln("    %s = 0;", protoValueName(enum, "Unspecified"))
+
+	for _, value := range enum.Values {
+		if value.Desc != "" {
+			writeln("    // %s %s", value.Name, utfer.RemoveNewlines(value.Desc))
+		}
+
+		writeln("    %s = %v;", protoValueName(enum, value.Name), value.ProtoNumber)
+	}
+
+	if len(enum.Proto.Reserved) > 0 {
+		reserved := append([]int{}, enum.Proto.Reserved...)
+		sort.Ints(reserved)
+
+		var ss []string
+
+		for _, number := range reserved {
+			ss = append(ss, fmt.Sprint(number))
+		}
+
+		writeln("    reserved %s;", strings.Join(ss, ", "))
+	}
+
+	writeln("}")
+
+	return []byte(sb.String())
+}
+
+// protoGoType splits a go-type such as github.com/acme/api/pb.Status into
+// its import path and type name.
+func protoGoType(goType string) (string, string) {
+	i := strings.LastIndex(goType, ".")
+
+	if i < 0 {
+		return "", goType
+	}
+
+	return goType[:i], goType[i+1:]
+}
+
+func templateProto(f *jen.File, enum enumer.EnumData, companionVar, companionStruct string) {
+	if enum.Proto == nil || enum.Proto.GoType == "" {
+		return
+	}
+
+	protoPath, protoName := protoGoType(enum.Proto.GoType)
+	protoType := func() *jen.Statement {
+		if protoPath == "" {
+			return jen.Id(protoName)
+		}
+
+		return jen.Qual(protoPath, protoName)
+	}
+
+	f.Comment(box("Protocol Buffers conversion")).Line()
+
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("ToProto").Params().Add(protoType()).Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+					jen.Return(protoType().Parens(jen.Lit(value.ProtoNumber))),
+				)
+			}
+
+			g.Default().Block(
+				jen.Return(protoType().Parens(jen.Lit(0))),
+			)
+		}),
+	).Line()
+
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("FromProto").Params(
+		jen.Id("v").Add(protoType()),
+	).Params(
+		jen.Id(enum.Type),
+		jen.Error(),
+	).Block(
+		jen.Switch(jen.Int32().Parens(jen.Id("v"))).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				g.Case(jen.Lit(value.ProtoNumber)).Block(
+					jen.Return(jen.Id(companionVar).Dot(value.Name), jen.Nil()),
+				)
+			}
+
+			g.Default().Block(
+				jen.Return(
+					jen.Id("t").Dot("Invalid"),
+					jen.Id("t").Dot("errf").Params(
+						jen.Id("v"),
+						jen.Qual("strings", "Join").Params(
+							jen.Id("t").Dot("ToStrings").Call(jen.Id("t").Dot("Values").Call().Op("...")),
+							jen.Lit(","),
+						),
+					),
+				),
+			)
+		}),
+	).Line()
+}
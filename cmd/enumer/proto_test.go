@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_persistProtoNumbers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		assigned map[int]int
+		retired  []int
+		expected string
+	}{
+		{
+			name: "adds numbers and keeps the layout",
+			input: `# The status.
+proto:
+    package: status
+values:
+    -   name: Pending # first
+        desc: |
+            Waiting.
+    -   name: Running
+
+
+    -   name: Done
+        proto-number: 0
+`,
+			assigned: map[int]int{0: 1, 1: 2, 2: 3},
+			expected: `# The status.
+proto:
+    package: status
+values:
+    -   name: Pending # first
+        proto-number: 1
+        desc: |
+            Waiting.
+    -   name: Running
+        proto-number: 2
+
+
+    -   name: Done
+        proto-number: 3
+`,
+		},
+		{
+			name: "adds reserved",
+			input: `proto:
+  package: status
+values:
+  - name: Done
+    proto-number: 3
+`,
+			retired: []int{1, 2},
+			expected: `proto:
+  package: status
+  reserved:
+      - 1
+      - 2
+values:
+  - name: Done
+    proto-number: 3
+`,
+		},
+		{
+			name: "appends to block reserved",
+			input: `proto:
+    reserved:
+        - 1
+values:
+    -   name: Done
+`,
+			retired: []int{2},
+			expected: `proto:
+    reserved:
+        - 1
+        - 2
+values:
+    -   name: Done
+`,
+		},
+		{
+			name: "appends to flow reserved",
+			input: `proto:
+    reserved: [1]
+values:
+    -   name: Done
+`,
+			retired: []int{2, 3},
+			expected: `proto:
+    reserved: [1, 2, 3]
+values:
+    -   name: Done
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			path := filepath.Join(tt.TempDir(), "status.enum.yaml")
+			assert.Nil(tt, os.WriteFile(path, []byte(tc.input), FilePermissions))
+			assert.Nil(tt, persistProtoNumbers(path, tc.assigned, tc.retired))
+
+			actual, err := os.ReadFile(path)
+
+			assert.Nil(tt, err)
+			assert.Equal(tt, tc.expected, string(actual))
+		})
+	}
+}
+
+func Test_processProto_retired(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "status.enum.yaml")
+	config := `proto:
+    package: status
+values:
+    -   name: Pending
+        proto-number: 1
+    -   name: Running
+        proto-number: 2
+    -   name: Failed
+`
+
+	assert.Nil(t, os.WriteFile(path, []byte(config), FilePermissions))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "status.proto"), []byte(`enum Status {
+    STATUS_UNSPECIFIED = 0;
+    STATUS_PENDING = 1;
+    STATUS_RUNNING = 2;
+    STATUS_DONE = 3;
+    reserved 4;
+}
+`), FilePermissions))
+
+	var enum enumer.EnumData
+
+	assert.Nil(t, processEnum(argsData{InputPath: path}, &enum))
+	assert.Nil(t, processProto(&enum, true))
+	assert.Equal(t, []int{3, 4}, enum.Proto.Reserved)
+	assert.Equal(t, 5, enum.Values[2].ProtoNumber)
+
+	actual, err := os.ReadFile(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `proto:
+    package: status
+    reserved:
+        - 3
+        - 4
+values:
+    -   name: Pending
+        proto-number: 1
+    -   name: Running
+        proto-number: 2
+    -   name: Failed
+        proto-number: 5
+`, string(actual))
+
+	enum = enumer.EnumData{}
+
+	assert.Nil(t, processEnum(argsData{InputPath: path}, &enum))
+
+	enum.Values[2].ProtoNumber = 3
+
+	assert.ErrorContains(t, processProto(&enum, false), "number is reserved")
+}
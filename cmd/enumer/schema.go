@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/extentioner"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+	"github.com/dave/jennifer/jen"
+)
+
+const (
+	schemaFormatJsonSchema = "json-schema"
+	schemaFormatOpenApi    = "openapi"
+)
+
+func processSchema(enum *enumer.EnumData) error {
+	if enum.Schema == nil {
+		return nil
+	}
+
+	switch enum.Schema.Format {
+	case "":
+		enum.Schema.Format = schemaFormatJsonSchema
+	case schemaFormatJsonSchema, schemaFormatOpenApi:
+	default:
+		return fmt.Errorf("invalid schema format %v: must be one of %v, %v",
+			enum.Schema.Format, schemaFormatJsonSchema, schemaFormatOpenApi)
+	}
+
+	if enum.Schema.OutputPath == "" {
+		enum.Schema.OutputPath = extentioner.Swap(enum.InputPath, ".yaml", ".schema.json")
+	}
+
+	return nil
+}
+
+func enumSchema(enum enumer.EnumData) map[string]any {
+	var values []any
+	var names []any
+	var descs []any
+
+	for _, value := range enum.Values {
+		values = append(values, value.Serialized)
+		names = append(names, value.Name)
+		descs = append(descs, utfer.RemoveNewlines(value.Desc))
+	}
+
+	schema := map[string]any{
+		"type":                "string",
+		"title":               enum.Type,
+		"enum":                values,
+		"x-enum-varnames":     names,
+		"x-enum-descriptions": descs,
+	}
+
+	if enum.Desc != "" {
+		schema["description"] = utfer.RemoveNewlines(enum.Desc)
+	}
+
+	return schema
+}
+
+func generateSchema(enum enumer.EnumData) ([]byte, error) {
+	var m map[string]any
+
+	switch enum.Schema.Format {
+	case schemaFormatOpenApi:
+		m = map[string]any{
+			"components": map[string]any{
+				"schemas": map[string]any{
+					enum.Type: enumSchema(enum),
+				},
+			},
+		}
+	default:
+		m = enumSchema(enum)
+		m["$schema"] = "http://json-schema.org/draft-07/schema"
+	}
+
+	return json.MarshalIndent(m, "", "    ")
+}
+
+func templateSchema(f *jen.File, enum enumer.EnumData, companionStruct string) {
+	if enum.Schema == nil {
+		return
+	}
+
+	f.Comment(box("Schema")).Line()
+
+	f.Comment("Schema returns the JSON Schema / OpenAPI 3 component for " + enum.Type)
+	f.Func().Params(jen.Id("t").Id(companionStruct)).Id("Schema").Params().Map(jen.String()).Any().Block(
+		jen.Return(schemaLit(enumSchema(enum))),
+	).Line()
+}
+
+func schemaLit(v any) jen.Code {
+	switch v := v.(type) {
+	case map[string]any:
+		var keys []string
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		return jen.Map(jen.String()).Any().ValuesFunc(func(g *jen.Group) {
+			for _, key := range keys {
+				g.Line().Lit(key).Op(":").Add(schemaLit(v[key]))
+			}
+			g.Line()
+		})
+	case []any:
+		return jen.Index().Any().ValuesFunc(func(g *jen.Group) {
+			for _, item := range v {
+				g.Line().Add(schemaLit(item))
+			}
+			g.Line()
+		})
+	default:
+		return jen.Lit(v)
+	}
+}
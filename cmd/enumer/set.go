@@ -0,0 +1,190 @@
+package main
+
+import (
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+// templateSet generates a bitset backed set type. Each value is assigned the
+// bit matching its position in the values list.
+func templateSet(f *jen.File, enum enumer.EnumData, companionVar string) {
+	if !hasFeature(enum, featureSet) {
+		return
+	}
+
+	setType := enum.Type + "Set"
+	indexFn := indexFn(enum)
+	words := (len(enum.Values) + 63) / 64
+
+	if words == 0 {
+		words = 1
+	}
+
+	f.Comment(box("Set implemenation")).Line()
+
+	f.Commentf("%s is a set of %s values.", setType, enum.Type)
+	f.Type().Id(setType).Struct(
+		jen.Id("bits").Index(jen.Lit(words)).Uint64(),
+	).Line()
+
+	f.Commentf("New%s returns a set containing items.", setType)
+	f.Func().Id("New"+setType).Params(jen.Id("items").Op("...").Id(enum.Type)).Id(setType).Block(
+		jen.Var().Id("s").Id(setType),
+		jen.Id("s").Dot("Add").Call(jen.Id("items").Op("...")),
+		jen.Return(jen.Id("s")),
+	).Line()
+
+	f.Comment("Add adds items to the set, ignoring invalid values.")
+	f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("Add").Params(jen.Id("items").Op("...").Id(enum.Type)).Block(
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("items")).Block(
+			jen.If(jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("item")), jen.Id("i").Op(">=").Lit(0)).Block(
+				jen.Id("s").Dot("bits").Index(jen.Id("i").Op("/").Lit(64)).Op("|=").Lit(1).Op("<<").Parens(jen.Id("i").Op("%").Lit(64)),
+			),
+		),
+	).Line()
+
+	f.Comment("Remove removes items from the set.")
+	f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("Remove").Params(jen.Id("items").Op("...").Id(enum.Type)).Block(
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("items")).Block(
+			jen.If(jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("item")), jen.Id("i").Op(">=").Lit(0)).Block(
+				jen.Id("s").Dot("bits").Index(jen.Id("i").Op("/").Lit(64)).Op("&^=").Lit(1).Op("<<").Parens(jen.Id("i").Op("%").Lit(64)),
+			),
+		),
+	).Line()
+
+	f.Comment("Contains reports whether item is in the set.")
+	f.Func().Params(jen.Id("s").Id(setType)).Id("Contains").Params(jen.Id("item").Id(enum.Type)).Bool().Block(
+		jen.Id("i").Op(":=").Id(indexFn).Call(jen.Id("item")),
+		jen.Return(
+			jen.Id("i").Op(">=").Lit(0).Op("&&").
+				Id("s").Dot("bits").Index(jen.Id("i").Op("/").Lit(64)).Op("&").
+				Parens(jen.Lit(1).Op("<<").Parens(jen.Id("i").Op("%").Lit(64))).Op("!=").Lit(0),
+		),
+	).Line()
+
+	algebra := func(name string, doc string, op string) {
+		f.Comment(doc)
+		f.Func().Params(jen.Id("s").Id(setType)).Id(name).Params(jen.Id("other").Id(setType)).Id(setType).Block(
+			jen.For(jen.Id("i").Op(":=").Range().Id("s").Dot("bits")).Block(
+				jen.Id("s").Dot("bits").Index(jen.Id("i")).Op(op).Id("other").Dot("bits").Index(jen.Id("i")),
+			),
+			jen.Return(jen.Id("s")),
+		).Line()
+	}
+
+	algebra("Union", "Union returns the values in either set.", "|=")
+	algebra("Intersect", "Intersect returns the values in both sets.", "&=")
+	algebra("Difference", "Difference returns the values in s which aren't in other.", "&^=")
+
+	f.Commentf("Complement returns the %s values which aren't in the set.", enum.Type)
+	f.Func().Params(jen.Id("s").Id(setType)).Id("Complement").Params().Id(setType).Block(
+		jen.Return(
+			jen.Id("New" + setType).Call(jen.Id(companionVar).Dot("Values").Call().Op("...")).Dot("Difference").Call(jen.Id("s")),
+		),
+	).Line()
+
+	f.Comment("Len returns the number of values in the set.")
+	f.Func().Params(jen.Id("s").Id(setType)).Id("Len").Params().Int().Block(
+		jen.Var().Id("n").Int().Line(),
+		jen.For(jen.Id("_").Op(",").Id("word").Op(":=").Range().Id("s").Dot("bits")).Block(
+			jen.Id("n").Op("+=").Qual("math/bits", "OnesCount64").Call(jen.Id("word")),
+		).Line(),
+		jen.Return(jen.Id("n")),
+	).Line()
+
+	f.Comment("IsEmpty reports whether the set has no values.")
+	f.Func().Params(jen.Id("s").Id(setType)).Id("IsEmpty").Params().Bool().Block(
+		jen.Return(jen.Id("s").Op("==").Id(setType).Values()),
+	).Line()
+
+	f.Commentf("Values returns the values in the set in %s order.", companionVar)
+	f.Func().Params(jen.Id("s").Id(setType)).Id("Values").Params().Index().Id(enum.Type).Block(
+		jen.Id("values").Op(":=").Make(jen.Index().Id(enum.Type), jen.Lit(0), jen.Id("s").Dot("Len").Call()).Line(),
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+			jen.If(jen.Id("s").Dot("Contains").Call(jen.Id("item"))).Block(
+				jen.Id("values").Op("=").Append(jen.Id("values"), jen.Id("item")),
+			),
+		).Line(),
+		jen.Return(jen.Id("values")),
+	).Line()
+
+	f.Func().Params(jen.Id("s").Id(setType)).Id("strings").Params().Index().String().Block(
+		jen.Id("ss").Op(":=").Make(jen.Index().String(), jen.Lit(0), jen.Id("s").Dot("Len").Call()).Line(),
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("s").Dot("Values").Call()).Block(
+			jen.Id("ss").Op("=").Append(jen.Id("ss"), jen.Id("item").Dot("String").Call()),
+		).Line(),
+		jen.Return(jen.Id("ss")),
+	).Line()
+
+	f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("parse").Params(jen.Id("ss").Index().String()).Error().Block(
+		jen.Var().Id("parsed").Id(setType).Line(),
+		jen.For(jen.Id("_").Op(",").Id("v").Op(":=").Range().Id("ss")).Block(
+			jen.Id("found").Op(",").Err().Op(":=").Id(companionVar).Dot("Parse").Call(jen.Id("v")).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Err())).Line(),
+			jen.Id("parsed").Dot("Add").Call(jen.Id("found")),
+		).Line(),
+		jen.Op("*").Id("s").Op("=").Id("parsed"),
+		jen.Return(jen.Nil()),
+	).Line()
+
+	if hasFeature(enum, featureJson) || hasFeature(enum, featureSql) {
+		f.Func().Params(jen.Id("s").Id(setType)).Id("MarshalJSON").Params().Params(jen.Index().Byte(), jen.Error()).Block(
+			jen.Return(jen.Qual("encoding/json", "Marshal").Call(jen.Id("s").Dot("strings").Call())),
+		).Line()
+
+		f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("UnmarshalJSON").Params(jen.Id("data").Index().Byte()).Error().Block(
+			jen.Var().Id("ss").Index().String().Line(),
+			jen.If(
+				jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("data"), jen.Op("&").Id("ss")),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Err())).Line(),
+			jen.Return(jen.Id("s").Dot("parse").Call(jen.Id("ss"))),
+		).Line()
+	}
+
+	if hasFeature(enum, featureYaml) {
+		f.Func().Params(jen.Id("s").Id(setType)).Id("MarshalYAML").Params().Params(jen.Interface(), jen.Error()).Block(
+			jen.Return(jen.Id("s").Dot("strings").Call(), jen.Nil()),
+		).Line()
+
+		f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("UnmarshalYAML").Params(
+			jen.Id("unmarshal").Func().Params(jen.Interface()).Error(),
+		).Error().Block(
+			jen.Var().Id("ss").Index().String().Line(),
+			jen.If(
+				jen.Err().Op(":=").Id("unmarshal").Call(jen.Op("&").Id("ss")),
+				jen.Err().Op("!=").Nil(),
+			).Block(jen.Return(jen.Err())).Line(),
+			jen.Return(jen.Id("s").Dot("parse").Call(jen.Id("ss"))),
+		).Line()
+	}
+
+	if hasFeature(enum, featureSql) {
+		f.Func().Params(jen.Id("s").Id(setType)).Id("Value").Params().Params(
+			jen.Qual("database/sql/driver", "Value"),
+			jen.Error(),
+		).Block(
+			jen.Id("bs").Op(",").Err().Op(":=").Id("s").Dot("MarshalJSON").Call().Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(jen.Return(jen.Nil(), jen.Err())).Line(),
+			jen.Return(jen.String().Parens(jen.Id("bs")), jen.Nil()),
+		).Line()
+
+		f.Func().Params(jen.Id("s").Op("*").Id(setType)).Id("Scan").Params(jen.Id("value").Interface()).Error().Block(
+			jen.Switch(jen.Id("v").Op(":=").Id("value").Assert(jen.Type())).Block(
+				jen.Case(jen.Nil()).Block(
+					jen.Op("*").Id("s").Op("=").Id(setType).Values(),
+					jen.Return(jen.Nil()),
+				),
+				jen.Case(jen.String()).Block(
+					jen.Return(jen.Id("s").Dot("UnmarshalJSON").Call(jen.Index().Byte().Parens(jen.Id("v")))),
+				),
+				jen.Case(jen.Index().Byte()).Block(
+					jen.Return(jen.Id("s").Dot("UnmarshalJSON").Call(jen.Id("v"))),
+				),
+				jen.Default().Block(
+					jen.Return(jen.Id(companionVar).Dot("errf").Params(jen.Id("value"))),
+				),
+			),
+		).Line()
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+)
+
+const (
+	directiveEnum      = "enumer:enum"
+	directiveParseFrom = "enumer:parse-from"
+)
+
+// processSource builds an enum for each type in the source file marked with
+// //enumer:enum. The type and its constants stay in the source file, and the
+// rest is generated into a sibling <type>.enum.go file.
+//
+//	//enumer:enum
+//	type Color string
+//
+//	const (
+//		// ColorRed is the color of fire.
+//		ColorRed Color = "red" //enumer:parse-from r rouge
+//	)
+func processSource(args argsData) ([]enumer.EnumData, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, args.SourcePath, nil, parser.ParseComments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var enums []enumer.EnumData
+
+	for _, found := range findGoEnums(fset, file) {
+		if !hasDirective(found.Directives, directiveEnum) {
+			continue
+		}
+
+		enum, err := sourceEnum(args, file, found)
+
+		if err != nil {
+			return nil, err
+		}
+
+		enums = append(enums, enum)
+	}
+
+	if len(enums) == 0 {
+		return nil, fmt.Errorf("invalid source path %v: no //%v types", args.SourcePath, directiveEnum)
+	}
+
+	return enums, nil
+}
+
+func sourceEnum(args argsData, file *ast.File, found goEnum) (enumer.EnumData, error) {
+	if found.Kind != "string" {
+		return enumer.EnumData{}, fmt.Errorf("%v: %v must be a string type", found.Pos, found.Type)
+	}
+
+	var enum enumer.EnumData
+
+	if err := processProject(args.SourcePath, &enum); err != nil {
+		return enumer.EnumData{}, err
+	}
+
+	enum.Type = found.Type
+	enum.Package = file.Name.Name
+	enum.OutputPath = filepath.Join(filepath.Dir(args.SourcePath), caser.PascalToKebabLower(found.Type)+".enum.go")
+	enum.Desc = found.Doc
+	enum.SkipType = true
+	enum.Overwrite = true
+	enum.Values = nil
+
+	for _, value := range found.Values {
+		if !value.Literal {
+			return enumer.EnumData{}, fmt.Errorf("%v: %v must be a string literal", found.Pos, value.Name)
+		}
+
+		enum.Values = append(enum.Values, enumer.EnumValue{
+			Name:       goValueName(found.Type, value.Name),
+			Serialized: value.Value,
+			Desc:       value.Doc,
+			ParseFrom:  directiveArgs(value.Directives, directiveParseFrom),
+		})
+	}
+
+	if err := processDefaults(argsData{InputPath: args.SourcePath, SkipFormat: args.SkipFormat, Debug: args.Debug}, &enum); err != nil {
+		return enumer.EnumData{}, err
+	}
+
+	return enum, nil
+}
+
+func hasDirective(directives []string, name string) bool {
+	for _, directive := range directives {
+		if directive == name || strings.HasPrefix(directive, name+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// directiveArgs returns the space separated arguments of every directive
+// named name.
+func directiveArgs(directives []string, name string) []string {
+	var args []string
+
+	for _, directive := range directives {
+		if strings.HasPrefix(directive, name+" ") {
+			args = append(args, strings.Fields(strings.TrimPrefix(directive, name))...)
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/caser"
+	"github.com/boundedinfinity/go-commoner/idiomatic/extentioner"
+	"github.com/boundedinfinity/go-commoner/idiomatic/stringer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/utfer"
+	"github.com/gertd/go-pluralize"
+)
+
+// processTemplates resolves template paths relative to the config file and
+// expands template directories into one entry per *.tmpl file.
+func processTemplates(enum *enumer.EnumData) error {
+	var templates []enumer.EnumTemplate
+	dir := filepath.Dir(enum.InputPath)
+
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+
+		return filepath.Join(dir, path)
+	}
+
+	for i, tmpl := range enum.Templates {
+		if tmpl.Path == "" {
+			return fmt.Errorf("invalid templates[%v]: missing path", i)
+		}
+
+		tmpl.Path = resolve(tmpl.Path)
+		tmpl.OutputPath = resolve(tmpl.OutputPath)
+
+		info, err := os.Stat(tmpl.Path)
+
+		if err != nil {
+			return fmt.Errorf("invalid templates[%v] path %v: %w", i, tmpl.Path, err)
+		}
+
+		if !info.IsDir() {
+			templates = append(templates, tmpl)
+			continue
+		}
+
+		paths, err := filepath.Glob(filepath.Join(tmpl.Path, "*.tmpl"))
+
+		if err != nil {
+			return fmt.Errorf("invalid templates[%v] path %v: %w", i, tmpl.Path, err)
+		}
+
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			item := enumer.EnumTemplate{
+				Path:    path,
+				Imports: tmpl.Imports,
+			}
+
+			if tmpl.OutputPath != "" {
+				name := extentioner.Strip(filepath.Base(path))
+				item.OutputPath = filepath.Join(tmpl.OutputPath, name)
+			}
+
+			templates = append(templates, item)
+		}
+	}
+
+	enum.Templates = templates
+
+	return nil
+}
+
+func templateFuncs() template.FuncMap {
+	client := pluralize.NewClient()
+
+	return template.FuncMap{
+		"convert": func(convert string, s string) (string, error) {
+			return caser.ConvertAs(s, convert)
+		},
+		"plural":     client.Plural,
+		"singular":   client.Singular,
+		"lowerFirst": stringer.ToLowerFirst[string],
+		"upperFirst": stringer.ToUpperFirst[string],
+		"oneLine":    utfer.RemoveNewlines[string],
+		"quote":      strconv.Quote,
+		"companion": func(enum enumer.EnumData) string {
+			return client.Plural(enum.Type)
+		},
+		"companionStruct": func(enum enumer.EnumData) string {
+			return stringer.ToLowerFirst(client.Plural(enum.Type))
+		},
+	}
+}
+
+func renderTemplate(enum enumer.EnumData, path string) ([]byte, error) {
+	bs, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't read template %v: %w", path, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs()).Parse(string(bs))
+
+	if err != nil {
+		return nil, fmt.Errorf("can't parse template %v: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, enum); err != nil {
+		return nil, fmt.Errorf("can't render template %v: %w", path, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// appendTemplates renders every template without an output path onto the end
+// of the generated Go source, adding any imports the templates declare.
+func appendTemplates(enum enumer.EnumData, src []byte) ([]byte, error) {
+	var appended [][]byte
+	var imports []string
+
+	for _, tmpl := range enum.Templates {
+		if tmpl.OutputPath != "" {
+			continue
+		}
+
+		bs, err := renderTemplate(enum, tmpl.Path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		appended = append(appended, bs)
+		imports = append(imports, tmpl.Imports...)
+	}
+
+	if len(appended) == 0 {
+		return src, nil
+	}
+
+	src, err := addImports(src, imports)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bs := range appended {
+		src = append(src, '\n')
+		src = append(src, bs...)
+	}
+
+	if enum.SkipFormat {
+		return src, nil
+	}
+
+	formatted, err := format.Source(src)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't format templates for %v: %w", enum.OutputPath, err)
+	}
+
+	return formatted, nil
+}
+
+func addImports(src []byte, imports []string) ([]byte, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ImportsOnly)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		existing[path] = true
+	}
+
+	var missing []string
+
+	for _, path := range imports {
+		if !existing[path] {
+			existing[path] = true
+			missing = append(missing, strconv.Quote(path))
+		}
+	}
+
+	if len(missing) == 0 {
+		return src, nil
+	}
+
+	decl := fmt.Sprintf("\n\nimport (\n%s\n)", strings.Join(missing, "\n"))
+	offset := int(file.Name.End()) - 1
+
+	var result []byte
+	result = append(result, src[:offset]...)
+	result = append(result, decl...)
+	result = append(result, src[offset:]...)
+
+	return result, nil
+}
+
+func writeTemplates(enum enumer.EnumData) error {
+	for _, tmpl := range enum.Templates {
+		if tmpl.OutputPath == "" {
+			continue
+		}
+
+		bs, err := renderTemplate(enum, tmpl.Path)
+
+		if err != nil {
+			return err
+		}
+
+		if !enum.SkipFormat && filepath.Ext(tmpl.OutputPath) == ".go" {
+			if bs, err = format.Source(bs); err != nil {
+				return fmt.Errorf("can't format template output %v: %w", tmpl.OutputPath, err)
+			}
+		}
+
+		if err := writeFile(tmpl.OutputPath, enum.Overwrite, bs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
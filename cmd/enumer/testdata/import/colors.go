@@ -0,0 +1,42 @@
+package colors
+
+// Color is the color of a widget.
+type Color string
+
+const (
+	// ColorRed is the color of fire.
+	ColorRed       Color = "red"
+	ColorLightBlue Color = "light-blue" // The color of the sky.
+	ColorGreen     Color = "green"      //enumer:parse-from g
+)
+
+// Level is a log level.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+)
+
+type Prefixed string
+
+const prefix = "p-"
+
+const (
+	PrefixedA Prefixed = prefix + "a"
+	PrefixedB Prefixed = "b"
+)
+
+type Unused string
+
+type Blank string
+
+const BlankNone Blank = ""
+
+// Priority is the priority of a task.
+type Priority int
+
+const (
+	PriorityHigh Priority = 20
+	PriorityLow  Priority = 0x0a
+)
@@ -0,0 +1,9 @@
+package colors
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	if got, err := Colors.Parse("g"); err != nil || got != ColorGreen {
+		t.Errorf("got %v, %v, want %v", got, err, ColorGreen)
+	}
+}
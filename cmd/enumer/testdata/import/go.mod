@@ -0,0 +1,5 @@
+module example.com/colors
+
+go 1.22
+
+require github.com/boundedinfinity/go-commoner v1.0.36
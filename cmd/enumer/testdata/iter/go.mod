@@ -0,0 +1,5 @@
+module example.com/iter
+
+go 1.23
+
+require github.com/boundedinfinity/go-commoner v1.0.36
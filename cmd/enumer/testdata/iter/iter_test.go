@@ -0,0 +1,47 @@
+package iter
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	if got := slices.Collect(Colors.All()); !slices.Equal(got, Colors.Values()) {
+		t.Errorf("got %v, want %v", got, Colors.Values())
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	for i, item := range Colors.Enumerate() {
+		if item != Colors.Values()[i] {
+			t.Errorf("%v: got %v, want %v", i, item, Colors.Values()[i])
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	got := slices.Collect(Colors.Filter(func(c Color) bool { return c != Colors.Green }))
+	want := []Color{Colors.Red, Colors.Blue, Colors.Orange}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAllGroup(t *testing.T) {
+	got := slices.Collect(Colors.AllWarm())
+	want := []Color{Colors.Red, Colors.Orange}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFilterByAttribute(t *testing.T) {
+	got := slices.Collect(Colors.FilterByKind("primary"))
+	want := []Color{Colors.Red, Colors.Green, Colors.Blue}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
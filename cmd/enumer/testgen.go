@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+	"github.com/dave/jennifer/jen"
+	"github.com/gertd/go-pluralize"
+)
+
+// testsOutputPath returns the path of the generated test file, which sits
+// next to the generated enum, e.g. my-string.enum.go has my-string_enum_test.go.
+func testsOutputPath(enum enumer.EnumData) string {
+	path := strings.TrimSuffix(enum.OutputPath, ".go")
+	path = strings.TrimSuffix(path, ".enum")
+	return path + "_enum_test.go"
+}
+
+// generateTests generates tests which round trip every value through each
+// generated encoding, check every parse-from alias, and fuzz Parse, along
+// with benchmarks of Parse against ParseFrom. They only use the standard
+// library so they build in any module.
+func generateTests(enum enumer.EnumData) ([]byte, error) {
+	companionVar := pluralize.NewClient().Plural(enum.Type)
+	name := func(s string) string { return "Test" + enum.Type + s }
+
+	f := jen.NewFile(enum.Package)
+	f.HeaderComment(enum.Header)
+
+	check := func(g *jen.Group, got jen.Code) {
+		g.If(jen.Add(got).Op("!=").Id("item")).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("got %v, want %v"), got, jen.Id("item")),
+		)
+	}
+
+	fatal := func(g *jen.Group) {
+		g.If(jen.Err().Op("!=").Nil()).Block(jen.Id("t").Dot("Fatal").Call(jen.Err()))
+	}
+
+	roundTrip := func(suffix string, body func(g *jen.Group)) {
+		f.Func().Id(name(suffix)).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+			jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id(companionVar).Dot("Values").Call()).BlockFunc(body),
+		).Line()
+	}
+
+	roundTrip("String", func(g *jen.Group) {
+		g.Id("got").Op(",").Err().Op(":=").Id(companionVar).Dot("Parse").Call(jen.Id("item").Dot("String").Call())
+		fatal(g)
+		check(g, jen.Id("got"))
+	})
+
+	if hasFeature(enum, featureJson) {
+		roundTrip("JSON", func(g *jen.Group) {
+			g.Id("bs").Op(",").Err().Op(":=").Qual("encoding/json", "Marshal").Call(jen.Id("item"))
+			fatal(g)
+			g.Var().Id("got").Id(enum.Type)
+			g.If(jen.Err().Op(":=").Qual("encoding/json", "Unmarshal").Call(jen.Id("bs"), jen.Op("&").Id("got")), jen.Err().Op("!=").Nil()).Block(
+				jen.Id("t").Dot("Fatal").Call(jen.Err()),
+			)
+			check(g, jen.Id("got"))
+		})
+	}
+
+	if hasFeature(enum, featureYaml) {
+		roundTrip("YAML", func(g *jen.Group) {
+			g.Id("v").Op(",").Err().Op(":=").Id("item").Dot("MarshalYAML").Call()
+			fatal(g)
+			g.Var().Id("got").Id(enum.Type)
+			g.Id("unmarshal").Op(":=").Func().Params(jen.Id("out").Interface()).Error().Block(
+				jen.Op("*").Id("out").Assert(jen.Op("*").String()).Op("=").Id("v").Assert(jen.String()),
+				jen.Return(jen.Nil()),
+			)
+			g.If(jen.Err().Op(":=").Id("got").Dot("UnmarshalYAML").Call(jen.Id("unmarshal")), jen.Err().Op("!=").Nil()).Block(
+				jen.Id("t").Dot("Fatal").Call(jen.Err()),
+			)
+			check(g, jen.Id("got"))
+		})
+	}
+
+	if hasFeature(enum, featureXml) {
+		roundTrip("XML", func(g *jen.Group) {
+			g.Id("bs").Op(",").Err().Op(":=").Qual("encoding/xml", "Marshal").Call(jen.Id("item"))
+			fatal(g)
+			g.Var().Id("got").Id(enum.Type)
+			g.If(jen.Err().Op(":=").Qual("encoding/xml", "Unmarshal").Call(jen.Id("bs"), jen.Op("&").Id("got")), jen.Err().Op("!=").Nil()).Block(
+				jen.Id("t").Dot("Fatal").Call(jen.Err()),
+			)
+			check(g, jen.Id("got"))
+		})
+	}
+
+	if hasFeature(enum, featureSql) {
+		roundTrip("SQL", func(g *jen.Group) {
+			g.Id("v").Op(",").Err().Op(":=").Id("item").Dot("Value").Call()
+			fatal(g)
+			g.Var().Id("got").Id(enum.Type)
+			g.If(jen.Err().Op(":=").Id("got").Dot("Scan").Call(jen.Id("v")), jen.Err().Op("!=").Nil()).Block(
+				jen.Id("t").Dot("Fatal").Call(jen.Err()),
+			)
+			check(g, jen.Id("got"))
+		})
+	}
+
+	// An alias listed under more than one value parses to the first, so only
+	// the first is expected.
+	seen := map[string]bool{}
+	aliases := jen.Dict{}
+
+	for _, value := range enum.Values {
+		for _, matcher := range parseMatchers(enum, value) {
+			if seen[matcher] {
+				continue
+			}
+
+			seen[matcher] = true
+
+			if slicer.Contains(matcher, value.ParseFrom...) {
+				aliases[jen.Lit(matcher)] = jen.Id(companionVar).Dot(value.Name)
+			}
+		}
+	}
+
+	if len(aliases) > 0 {
+		f.Func().Id(name("ParseFrom")).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+			jen.For(jen.Id("alias").Op(",").Id("item").Op(":=").Range().Map(jen.String()).Id(enum.Type).Values(aliases)).BlockFunc(func(g *jen.Group) {
+				g.Id("got").Op(",").Err().Op(":=").Id(companionVar).Dot("Parse").Call(jen.Id("alias"))
+				fatal(g)
+				check(g, jen.Id("got"))
+			}),
+		).Line()
+	}
+
+	benchmark := func(name string, parse func(v jen.Code) jen.Code) {
+		f.Func().Id("Benchmark"+enum.Type+name).Params(jen.Id("b").Op("*").Qual("testing", "B")).Block(
+			jen.Id("inputs").Op(":=").Id(companionVar).Dot("ToStrings").Call(jen.Id(companionVar).Dot("Values").Call().Op("...")),
+			jen.Id("b").Dot("ReportAllocs").Call(),
+			jen.Id("b").Dot("ResetTimer").Call().Line(),
+			jen.For(jen.Id("i").Op(":=").Lit(0), jen.Id("i").Op("<").Id("b").Dot("N"), jen.Id("i").Op("++")).Block(
+				jen.Id("_").Op(",").Id("_").Op("=").Add(parse(jen.Id("inputs").Index(jen.Id("i").Op("%").Len(jen.Id("inputs"))))),
+			),
+		).Line()
+	}
+
+	// Parse is a switch, and ParseFrom scans the values it is given, which
+	// the benchmarks compare.
+	benchmark("Parse", func(v jen.Code) jen.Code {
+		return jen.Id(companionVar).Dot("Parse").Call(v)
+	})
+
+	benchmark("ParseFrom", func(v jen.Code) jen.Code {
+		return jen.Id(companionVar).Dot("ParseFrom").Call(v, jen.Id(companionVar).Dot("Values").Call().Op("..."))
+	})
+
+	f.Func().Id(name("ParseAllocs")).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+		jen.Id("inputs").Op(":=").Id(companionVar).Dot("ToStrings").Call(jen.Id(companionVar).Dot("Values").Call().Op("...")).Line(),
+		jen.Id("allocs").Op(":=").Qual("testing", "AllocsPerRun").Call(jen.Lit(100), jen.Func().Params().Block(
+			jen.For(jen.Id("_").Op(",").Id("v").Op(":=").Range().Id("inputs")).Block(
+				jen.Id("_").Op(",").Id("_").Op("=").Id(companionVar).Dot("Parse").Call(jen.Id("v")),
+			),
+		)).Line(),
+		jen.If(jen.Id("allocs").Op("!=").Lit(0)).Block(
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("got %v allocations, want 0"), jen.Id("allocs")),
+		),
+	).Line()
+
+	f.Func().Id("Fuzz"+enum.Type+"Parse").Params(jen.Id("f").Op("*").Qual("testing", "F")).Block(
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+			jen.Id("f").Dot("Add").Call(jen.Id("item").Dot("String").Call()),
+		),
+		jen.Id("f").Dot("Add").Call(jen.Lit("")).Line(),
+		jen.Id("f").Dot("Fuzz").Call(jen.Func().Params(jen.Id("t").Op("*").Qual("testing", "T"), jen.Id("v").String()).Block(
+			jen.Id("got").Op(",").Err().Op(":=").Id(companionVar).Dot("Parse").Call(jen.Id("v")).Line(),
+			jen.If(jen.Err().Op("!=").Nil()).Block(
+				jen.If(jen.Op("!").Qual("errors", "Is").Call(jen.Err(), jen.Id(companionVar).Dot("Err"))).Block(
+					jen.Id("t").Dot("Errorf").Call(jen.Lit("%q: got %v, want %v"), jen.Id("v"), jen.Err(), jen.Id(companionVar).Dot("Err")),
+				),
+				jen.If(jen.Id("got").Op("!=").Id(companionVar).Dot("Invalid")).Block(
+					jen.Id("t").Dot("Errorf").Call(jen.Lit("%q: got %v, want %v"), jen.Id("v"), jen.Id("got"), jen.Id(companionVar).Dot("Invalid")),
+				),
+				jen.Return(),
+			).Line(),
+			jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id(companionVar).Dot("Values").Call()).Block(
+				jen.If(jen.Id("got").Op("==").Id("item")).Block(jen.Return()),
+			).Line(),
+			jen.Id("t").Dot("Errorf").Call(jen.Lit("%q: got %v, which isn't a %s value"), jen.Id("v"), jen.Id("got"), jen.Lit(enum.Type)),
+		)),
+	).Line()
+
+	return []byte(fmt.Sprintf("%#v", f)), nil
+}
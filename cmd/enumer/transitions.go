@@ -0,0 +1,264 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+)
+
+// findValue returns the value referenced by ref. A value named ref always
+// wins, otherwise ref may be the Go identifier or serialized form of one
+// value, but not of several.
+func findValue(enum enumer.EnumData, ref string) (enumer.EnumValue, error) {
+	for _, value := range enum.Values {
+		if value.Name == ref {
+			return value, nil
+		}
+	}
+
+	ident, err := goIdentifier(ref)
+
+	if err != nil {
+		ident = ""
+	}
+
+	var found []enumer.EnumValue
+
+	for _, value := range enum.Values {
+		if value.Serialized == ref || (ident != "" && value.Name == ident) {
+			found = append(found, value)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return enumer.EnumValue{}, fmt.Errorf("unknown value %q", ref)
+	case 1:
+		return found[0], nil
+	default:
+		return enumer.EnumValue{}, fmt.Errorf("ambiguous value %q: matches %v and %v", ref, found[0].Name, found[1].Name)
+	}
+}
+
+// enumTransitions resolves the transitions section into the Go names of
+// each value's legal next values, ordered by the values list.
+func enumTransitions(enum enumer.EnumData) (map[string][]string, error) {
+	edges := map[string]map[string]bool{}
+
+	for from, tos := range enum.Transitions {
+		fromValue, err := findValue(enum, from)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w in transitions", err)
+		}
+
+		if edges[fromValue.Name] == nil {
+			edges[fromValue.Name] = map[string]bool{}
+		}
+
+		for _, to := range tos {
+			toValue, err := findValue(enum, to)
+
+			if err != nil {
+				return nil, fmt.Errorf("%w in transitions %v", err, from)
+			}
+
+			edges[fromValue.Name][toValue.Name] = true
+		}
+	}
+
+	transitions := map[string][]string{}
+
+	for _, from := range enum.Values {
+		for _, to := range enum.Values {
+			if edges[from.Name][to.Name] {
+				transitions[from.Name] = append(transitions[from.Name], to.Name)
+			}
+		}
+	}
+
+	return transitions, nil
+}
+
+func processTransitions(enum *enumer.EnumData) error {
+	_, err := enumTransitions(*enum)
+	return err
+}
+
+func templateTransitions(f *jen.File, enum enumer.EnumData, companionVar string) error {
+	if len(enum.Transitions) == 0 {
+		return nil
+	}
+
+	transitions, err := enumTransitions(enum)
+
+	if err != nil {
+		return err
+	}
+
+	incoming := map[string]bool{}
+
+	for _, tos := range transitions {
+		for _, to := range tos {
+			incoming[to] = true
+		}
+	}
+
+	values := func(names []string) *jen.Statement {
+		return jen.Index().Id(enum.Type).ValuesFunc(func(g *jen.Group) {
+			for _, name := range names {
+				g.Id(companionVar).Dot(name)
+			}
+		})
+	}
+
+	matching := func(fn func(enumer.EnumValue) bool) []string {
+		var names []string
+
+		for _, value := range enum.Values {
+			if fn(value) {
+				names = append(names, value.Name)
+			}
+		}
+
+		return names
+	}
+
+	is := func(name string, doc string, names []string) {
+		f.Comment(doc)
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id(name).Params().Bool().BlockFunc(func(g *jen.Group) {
+			if len(names) == 0 {
+				g.Return(jen.False())
+				return
+			}
+
+			g.Switch(jen.Id("t")).Block(
+				jen.CaseFunc(func(g *jen.Group) {
+					for _, name := range names {
+						g.Id(companionVar).Dot(name)
+					}
+				}).Block(jen.Return(jen.True())),
+				jen.Default().Block(jen.Return(jen.False())),
+			)
+		}).Line()
+	}
+
+	f.Comment(box("Transitions")).Line()
+
+	f.Comment("Transitions returns the values t can transition to.")
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("Transitions").Params().Index().Id(enum.Type).Block(
+		jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+			for _, value := range enum.Values {
+				if len(transitions[value.Name]) == 0 {
+					continue
+				}
+
+				g.Case(jen.Id(companionVar).Dot(value.Name)).Block(
+					jen.Return(values(transitions[value.Name])),
+				)
+			}
+
+			g.Default().Block(jen.Return(jen.Nil()))
+		}),
+	).Line()
+
+	f.Comment("CanTransitionTo reports whether t can transition to next.")
+	f.Func().Params(jen.Id("t").Id(enum.Type)).Id("CanTransitionTo").Params(jen.Id("next").Id(enum.Type)).Bool().Block(
+		jen.For(jen.Id("_").Op(",").Id("item").Op(":=").Range().Id("t").Dot("Transitions").Call()).Block(
+			jen.If(jen.Id("item").Op("==").Id("next")).Block(jen.Return(jen.True())),
+		).Line(),
+		jen.Return(jen.False()),
+	).Line()
+
+	is("IsInitial", "IsInitial reports whether no value can transition to t.", matching(func(value enumer.EnumValue) bool {
+		return !incoming[value.Name]
+	}))
+
+	is("IsTerminal", "IsTerminal reports whether t can't transition to any value.", matching(func(value enumer.EnumValue) bool {
+		return len(transitions[value.Name]) == 0
+	}))
+
+	return nil
+}
+
+func generateDot(enum enumer.EnumData) ([]byte, error) {
+	transitions, err := enumTransitions(enum)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	writeln := func(format string, a ...any) {
+		sb.WriteString(fmt.Sprintf(format, a...) + "\n")
+	}
+
+	writeln("digraph %q {", enum.Type)
+
+	for _, value := range enum.Values {
+		writeln("    %q;", value.Serialized)
+	}
+
+	for _, from := range enum.Values {
+		for _, name := range transitions[from.Name] {
+			to, _ := findValue(enum, name)
+			writeln("    %q -> %q;", from.Serialized, to.Serialized)
+		}
+	}
+
+	writeln("}")
+
+	return []byte(sb.String()), nil
+}
+
+type dotArgsData struct {
+	InputPath  string
+	OutputPath string
+}
+
+func processDot(arguments []string) error {
+	var args dotArgsData
+
+	flags := flag.NewFlagSet("dot", flag.ExitOnError)
+	flags.StringVar(&args.InputPath, "config", "", "The input file used for the enum being exported.")
+	flags.StringVar(&args.OutputPath, "output", "", "The file the Graphviz DOT graph is written to, defaults to stdout.")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if args.InputPath == "" {
+		return errors.New("missing config path")
+	}
+
+	if absPath, err := filepath.Abs(args.InputPath); err != nil {
+		return err
+	} else {
+		args.InputPath = absPath
+	}
+
+	var enum enumer.EnumData
+
+	if err := processEnum(argsData{InputPath: args.InputPath}, &enum); err != nil {
+		return err
+	}
+
+	bs, err := generateDot(enum)
+
+	if err != nil {
+		return err
+	}
+
+	if args.OutputPath == "" {
+		_, err := os.Stdout.Write(bs)
+		return err
+	}
+
+	return os.WriteFile(args.OutputPath, bs, FilePermissions)
+}
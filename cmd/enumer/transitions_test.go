@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findValue(t *testing.T) {
+	enum := enumer.EnumData{Values: []enumer.EnumValue{
+		{Name: "Open", Serialized: "Closed"},
+		{Name: "Closed", Serialized: "shut"},
+		{Name: "Ajar", Serialized: "half open"},
+		{Name: "HalfOpen", Serialized: "half"},
+	}}
+
+	testCases := []struct {
+		name     string
+		ref      string
+		expected string
+		err      string
+	}{
+		{name: "name before serialized", ref: "Closed", expected: "Closed"},
+		{name: "serialized", ref: "shut", expected: "Closed"},
+		{name: "identifier", ref: "open", expected: "Open"},
+		{name: "ambiguous", ref: "half open", err: `ambiguous value "half open": matches Ajar and HalfOpen`},
+		{name: "unknown", ref: "wide", err: `unknown value "wide"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			actual, err := findValue(enum, tc.ref)
+
+			if tc.err == "" {
+				assert.Nil(tt, err)
+				assert.Equal(tt, tc.expected, actual.Name)
+			} else {
+				assert.EqualError(tt, err, tc.err)
+			}
+		})
+	}
+}
+
+func Test_enumTransitions(t *testing.T) {
+	values := []enumer.EnumValue{
+		{Name: "Pending", Serialized: "pending"},
+		{Name: "InProgress", Serialized: "in-progress"},
+		{Name: "Done", Serialized: "done"},
+	}
+
+	testCases := []struct {
+		name        string
+		transitions map[string][]string
+		expected    map[string][]string
+		err         string
+	}{
+		{
+			name:        "references",
+			transitions: map[string][]string{"pending": {"In Progress", "Done"}, "InProgress": {"done"}},
+			expected:    map[string][]string{"Pending": {"InProgress", "Done"}, "InProgress": {"Done"}},
+		},
+		{
+			name:        "unknown from",
+			transitions: map[string][]string{"?": {"done"}},
+			err:         `unknown value "?" in transitions`,
+		},
+		{
+			name:        "unknown to",
+			transitions: map[string][]string{"pending": {"-"}},
+			err:         `unknown value "-" in transitions pending`,
+		},
+		{
+			name:        "empty",
+			transitions: map[string][]string{"": {"done"}},
+			err:         `unknown value "" in transitions`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			actual, err := enumTransitions(enumer.EnumData{Type: "Status", Values: values, Transitions: tc.transitions})
+
+			if tc.err == "" {
+				assert.Nil(tt, err)
+				assert.Equal(tt, tc.expected, actual)
+			} else {
+				assert.EqualError(tt, err, tc.err)
+			}
+		})
+	}
+}
+
+func Test_generateDot(t *testing.T) {
+	actual, err := generateDot(enumer.EnumData{
+		Type: "Door",
+		Values: []enumer.EnumValue{
+			{Name: "Open", Serialized: "open"},
+			{Name: "Closed", Serialized: "closed"},
+			{Name: "Locked", Serialized: "locked \"tight\""},
+		},
+		Transitions: map[string][]string{
+			"open":   {"closed"},
+			"closed": {"open", "locked"},
+			"locked": {"closed"},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `digraph "Door" {
+    "open";
+    "closed";
+    "locked \"tight\"";
+    "open" -> "closed";
+    "closed" -> "open";
+    "closed" -> "locked \"tight\"";
+    "locked \"tight\"" -> "closed";
+}
+`, string(actual))
+}
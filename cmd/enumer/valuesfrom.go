@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+	"github.com/dave/jennifer/jen"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	valuesFromCsv  = "csv"
+	valuesFromJson = "json"
+	valuesFromYaml = "yaml"
+)
+
+// valuesFromFields are the value fields a column can be mapped to.
+var valuesFromFields = []string{"name", "serialized", "desc", "parse-from"}
+
+// processValuesFrom loads the values-from data file. Each row becomes a value
+// which comes before the values listed in the config. Columns map a value
+// field to the column holding it, and default to a column of the same name.
+func processValuesFrom(enum *enumer.EnumData) ([]enumer.EnumValue, error) {
+	from := enum.ValuesFrom
+
+	if from == nil {
+		return nil, nil
+	}
+
+	if from.Path == "" {
+		return nil, fmt.Errorf("invalid values-from: missing path")
+	}
+
+	path := from.Path
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(enum.InputPath), path)
+	}
+
+	format := from.Format
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+
+		if format == "yml" {
+			format = valuesFromYaml
+		}
+	}
+
+	for field := range from.Columns {
+		if !slicer.Contains(field, valuesFromFields...) {
+			return nil, fmt.Errorf("invalid values-from columns %v: must be one of %v", field, strings.Join(valuesFromFields, ", "))
+		}
+	}
+
+	bs, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("can't load values-from path %v : %w", path, err)
+	}
+
+	var header []string
+	var rows []map[string][]string
+
+	switch format {
+	case valuesFromCsv:
+		header, rows, err = valuesFromCsvRows(bs)
+	case valuesFromJson:
+		var items []map[string]any
+
+		if err = json.Unmarshal(bs, &items); err == nil {
+			header, rows = valuesFromItems(items)
+		}
+	case valuesFromYaml:
+		var items []map[string]any
+
+		if err = yaml.Unmarshal(bs, &items); err == nil {
+			header, rows = valuesFromItems(items)
+		}
+	default:
+		return nil, fmt.Errorf("invalid values-from format %v: must be one of %v, %v, %v", format, valuesFromCsv, valuesFromJson, valuesFromYaml)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can't parse values-from path %v : %w", path, err)
+	}
+
+	for _, field := range sortedKeys(from.Columns) {
+		if column := from.Columns[field]; !slicer.Contains(column, header...) {
+			return nil, fmt.Errorf("invalid values-from columns %v: missing column %v in %v", field, column, path)
+		}
+	}
+
+	for _, attribute := range sortedKeys(from.Attributes) {
+		if column := from.Attributes[attribute]; !slicer.Contains(column, header...) {
+			return nil, fmt.Errorf("invalid values-from attributes %v: missing column %v in %v", attribute, column, path)
+		}
+	}
+
+	separator := from.Separator
+
+	if separator == "" {
+		separator = "|"
+	}
+
+	cell := func(row map[string][]string, field string) []string {
+		column := field
+
+		if mapped, ok := from.Columns[field]; ok {
+			column = mapped
+		}
+
+		return row[column]
+	}
+
+	first := func(cells []string) string {
+		if len(cells) == 0 {
+			return ""
+		}
+
+		return cells[0]
+	}
+
+	var values []enumer.EnumValue
+
+	for _, row := range rows {
+		value := enumer.EnumValue{
+			Name:       first(cell(row, "name")),
+			Serialized: first(cell(row, "serialized")),
+			Desc:       first(cell(row, "desc")),
+		}
+
+		for _, aliases := range cell(row, "parse-from") {
+			for _, alias := range strings.Split(aliases, separator) {
+				if alias = strings.TrimSpace(alias); alias != "" {
+					value.ParseFrom = append(value.ParseFrom, alias)
+				}
+			}
+		}
+
+		for attribute, column := range from.Attributes {
+			if value.Attributes == nil {
+				value.Attributes = map[string]string{}
+			}
+
+			value.Attributes[attribute] = first(row[column])
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// valuesFromCsvRows reads the header and the rows of a CSV file with a
+// header row. A byte order mark, which spreadsheets write, is dropped from
+// the first column.
+func valuesFromCsvRows(bs []byte) ([]string, []map[string][]string, error) {
+	records, err := csv.NewReader(strings.NewReader(string(bs))).ReadAll()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	var header []string
+
+	for i, column := range records[0] {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+
+		header = append(header, strings.TrimSpace(column))
+	}
+
+	var rows []map[string][]string
+
+	for _, record := range records[1:] {
+		row := map[string][]string{}
+
+		for i, column := range header {
+			if i < len(record) && record[i] != "" {
+				row[column] = []string{record[i]}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return header, rows, nil
+}
+
+// valuesFromItems converts JSON or YAML objects to rows, and returns the keys
+// of any object as the header. Lists keep each of their items, and other
+// values are formatted as strings.
+func valuesFromItems(items []map[string]any) ([]string, []map[string][]string) {
+	var header []string
+	var rows []map[string][]string
+
+	for _, item := range items {
+		row := map[string][]string{}
+
+		for key, value := range item {
+			if !slicer.Contains(key, header...) {
+				header = append(header, key)
+			}
+
+			switch value := value.(type) {
+			case nil:
+			case []any:
+				for _, v := range value {
+					row[key] = append(row[key], fmt.Sprint(v))
+				}
+			default:
+				row[key] = []string{fmt.Sprint(value)}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return header, rows
+}
+
+// enumAttributes returns the attribute names used by any value, sorted.
+func enumAttributes(enum enumer.EnumData) []string {
+	seen := map[string]bool{}
+	var names []string
+
+	for _, value := range enum.Values {
+		for name := range value.Attributes {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// templateAttributes generates a method returning each attribute, which is
+// empty for values without it.
+func templateAttributes(f *jen.File, enum enumer.EnumData, companionVar string) error {
+	names := enumAttributes(enum)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	f.Comment(box("Attributes")).Line()
+
+	idents := map[string]string{}
+
+	for _, name := range names {
+		ident, err := goIdentifier(name)
+
+		if err != nil {
+			return fmt.Errorf("invalid attribute %v: %w", name, err)
+		}
+
+		for _, other := range typeMethods {
+			if ident == other {
+				return fmt.Errorf("invalid attribute %v: conflicts with %v", name, other)
+			}
+		}
+
+		if other, ok := idents[ident]; ok {
+			return fmt.Errorf("invalid attribute %v: conflicts with %v", name, other)
+		}
+
+		idents[ident] = name
+
+		f.Commentf("%s returns the %s attribute of t.", ident, name)
+		f.Func().Params(jen.Id("t").Id(enum.Type)).Id(ident).Params().String().Block(
+			jen.Switch(jen.Id("t")).BlockFunc(func(g *jen.Group) {
+				for _, value := range enum.Values {
+					if attribute, ok := value.Attributes[name]; ok {
+						g.Case(jen.Id(companionVar).Dot(value.Name)).Block(jen.Return(jen.Lit(attribute)))
+					}
+				}
+
+				g.Default().Block(jen.Return(jen.Lit("")))
+			}),
+		).Line()
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of m, sorted so errors are reported in a
+// stable order.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
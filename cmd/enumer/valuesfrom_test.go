@@ -0,0 +1,116 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/dave/jennifer/jen"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_processEnum_values_from_override(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"countries.csv": "\ufeffname,serialized,desc,code,region\nJapan,jp,Japan,392,\nPeru,pe,Peru,604,Americas\n",
+		"country.enum.yaml": `values-from:
+    path: countries.csv
+    attributes:
+        code: code
+        region: region
+values:
+    -   name: Japan
+        desc: Nippon
+        attributes:
+            region: Asia
+    -   name: Chile
+`,
+	})
+
+	var enum enumer.EnumData
+
+	assert.Nil(t, processEnum(argsData{InputPath: filepath.Join(dir, "country.enum.yaml")}, &enum))
+	assert.Equal(t, []enumer.EnumValue{
+		{Name: "Japan", Serialized: "jp", Desc: "Nippon", Attributes: map[string]string{"code": "392", "region": "Asia"}},
+		{Name: "Peru", Serialized: "pe", Desc: "Peru", Attributes: map[string]string{"code": "604", "region": "Americas"}},
+		{Name: "Chile", Serialized: "chile"},
+	}, enum.Values)
+}
+
+func Test_processEnum_values_from_missing_column(t *testing.T) {
+	testCases := []struct {
+		name   string
+		file   string
+		data   string
+		config string
+		err    string
+	}{
+		{
+			name:   "csv column",
+			file:   "countries.csv",
+			data:   "name,code\nJapan,392\n",
+			config: "columns:\n        desc: description\n",
+			err:    "invalid values-from columns desc: missing column description in",
+		},
+		{
+			name:   "csv attribute",
+			file:   "countries.csv",
+			data:   "name,code\nJapan,392\n",
+			config: "attributes:\n        region: region\n",
+			err:    "invalid values-from attributes region: missing column region in",
+		},
+		{
+			name:   "json attribute",
+			file:   "countries.json",
+			data:   `[{"name": "Japan", "code": 392}]`,
+			config: "attributes:\n        code: iso\n",
+			err:    "invalid values-from attributes code: missing column iso in",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			dir := tt.TempDir()
+
+			writeTestFiles(tt, dir, map[string]string{
+				tc.file:             tc.data,
+				"country.enum.yaml": "values-from:\n    path: " + tc.file + "\n    " + tc.config,
+			})
+
+			var enum enumer.EnumData
+
+			assert.ErrorContains(tt, processEnum(argsData{InputPath: filepath.Join(dir, "country.enum.yaml")}, &enum), tc.err)
+		})
+	}
+}
+
+func Test_templateAttributes_invalid(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attribute string
+		err       string
+	}{
+		{name: "empty", attribute: "", err: "must contain a letter or digit"},
+		{name: "symbols", attribute: "!!!", err: "must contain a letter or digit"},
+		{name: "type method", attribute: "string", err: "conflicts with String"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			enum := enumer.EnumData{
+				Type:   "Country",
+				Values: []enumer.EnumValue{{Name: "Japan", Attributes: map[string]string{tc.attribute: "x"}}},
+			}
+
+			assert.ErrorContains(tt, templateAttributes(jen.NewFile("countries"), enum, "Countries"), tc.err)
+		})
+	}
+
+	enum := enumer.EnumData{
+		Type:   "Country",
+		Values: []enumer.EnumValue{{Name: "Japan", Attributes: map[string]string{"iso-code": "x", "iso_code": "y"}}},
+	}
+
+	assert.ErrorContains(t, templateAttributes(jen.NewFile("countries"), enum, "Countries"), "invalid attribute iso_code: conflicts with iso-code")
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/boundedinfinity/enumer"
+	"github.com/boundedinfinity/go-commoner/idiomatic/slicer"
+)
+
+// processWatch polls the configs under args.WatchPath, and the files they
+// reference, and regenerates the configs affected by a change. Changes are
+// debounced until a poll finds nothing new, and errors are printed without
+// stopping the watch.
+func processWatch(args argsData) error {
+	root, err := filepath.Abs(args.WatchPath)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(root); err != nil {
+		return fmt.Errorf("invalid watch path %v: %w", root, err)
+	}
+
+	deps := map[string][]string{}
+	pending := map[string]bool{}
+
+	scan := func() map[string]time.Time {
+		paths, err := findEnumConfigs(root)
+
+		if err != nil {
+			fmt.Println(err.Error())
+			return nil
+		}
+
+		var files []string
+
+		for _, path := range paths {
+			if _, ok := deps[path]; !ok {
+				deps[path], _ = watchDeps(args, path)
+			}
+
+			files = append(files, deps[path]...)
+		}
+
+		for path := range deps {
+			if !slicer.Contains(path, paths...) {
+				delete(deps, path)
+			}
+		}
+
+		return watchTimes(files)
+	}
+
+	previous := scan()
+	fmt.Printf("watching %v enum config(s) in %v\n", len(deps), root)
+
+	for {
+		time.Sleep(args.WatchInterval)
+
+		current := scan()
+		changed := watchChanged(previous, current)
+
+		for _, path := range changed {
+			pending[path] = true
+		}
+
+		previous = current
+
+		if len(changed) > 0 || len(pending) == 0 {
+			continue
+		}
+
+		var configs []string
+
+		for config, files := range deps {
+			for _, file := range files {
+				if pending[file] {
+					configs = append(configs, config)
+					break
+				}
+			}
+		}
+
+		sort.Strings(configs)
+
+		for _, config := range configs {
+			// A config which doesn't load keeps its last dependencies, so
+			// the files it referenced are still watched until it is fixed.
+			if files, err := watchDeps(args, config); err == nil {
+				deps[config] = files
+			}
+
+			if err := watchGenerate(args, config); err != nil {
+				fmt.Printf("error %v: %v\n", config, err)
+			} else {
+				fmt.Printf("generated %v\n", config)
+			}
+		}
+
+		pending = map[string]bool{}
+		previous = scan()
+	}
+}
+
+// watchTimes returns the modification time of each file, which is the zero
+// time for a missing file.
+func watchTimes(files []string) map[string]time.Time {
+	times := map[string]time.Time{}
+
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		} else {
+			times[file] = time.Time{}
+		}
+	}
+
+	return times
+}
+
+// watchChanged returns the files which changed between two polls, including
+// files which appeared, vanished, or stopped being watched.
+func watchChanged(previous, current map[string]time.Time) []string {
+	var changed []string
+
+	for path, modTime := range current {
+		if last, ok := previous[path]; !ok || !last.Equal(modTime) {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed
+}
+
+// watchDeps returns the config and the files it references which change its
+// outputs, following the configs it extends, includes and maps to along with
+// their own files. A template directory is watched along with its templates,
+// so added and removed templates are noticed. The error is why the config
+// didn't load, in which case only the files found before are returned.
+func watchDeps(args argsData, path string) ([]string, error) {
+	var files []string
+	seen := map[string]bool{}
+
+	add := func(file string) bool {
+		if seen[file] {
+			return false
+		}
+
+		seen[file] = true
+		files = append(files, file)
+		return true
+	}
+
+	var walk func(path string) error
+
+	walk = func(path string) error {
+		if !add(path) {
+			return nil
+		}
+
+		if project, ok := findProject(filepath.Dir(path)); ok {
+			add(project)
+		}
+
+		var enum enumer.EnumData
+
+		if err := processEnum(argsData{InputPath: path}, &enum); err != nil {
+			return err
+		}
+
+		if enum.HeaderFrom != "" {
+			if abs, err := filepath.Abs(enum.HeaderFrom); err == nil {
+				add(abs)
+			}
+		}
+
+		resolve := func(dep string) string {
+			if !filepath.IsAbs(dep) {
+				dep = filepath.Join(filepath.Dir(path), dep)
+			}
+
+			return dep
+		}
+
+		configs := append([]string{}, enum.Include...)
+
+		if enum.Extends != "" {
+			configs = append(configs, enum.Extends)
+		}
+
+		for _, mapsTo := range enum.MapsTo {
+			configs = append(configs, mapsTo.Config)
+		}
+
+		for _, config := range configs {
+			// A config which doesn't load fails this one too, or is
+			// reported when it is generated itself.
+			_ = walk(resolve(config))
+		}
+
+		var refs []string
+
+		if enum.ValuesFrom != nil && enum.ValuesFrom.Path != "" {
+			refs = append(refs, enum.ValuesFrom.Path)
+		}
+
+		for _, tmpl := range enum.Templates {
+			refs = append(refs, tmpl.Path)
+		}
+
+		for _, dep := range refs {
+			dep = resolve(dep)
+			add(dep)
+
+			if info, err := os.Stat(dep); err == nil && info.IsDir() {
+				templates, _ := filepath.Glob(filepath.Join(dep, "*.tmpl"))
+
+				for _, tmpl := range templates {
+					add(tmpl)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	err := walk(path)
+	return files, err
+}
+
+func watchGenerate(args argsData, path string) error {
+	var enum enumer.EnumData
+
+	if err := processEnum(argsData{
+		InputPath:  path,
+		SkipFormat: args.SkipFormat,
+		Debug:      args.Debug,
+		Overwrite:  true,
+	}, &enum); err != nil {
+		return err
+	}
+
+	return processGenerate(enum)
+}
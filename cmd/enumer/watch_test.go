@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_watchChanged(t *testing.T) {
+	t1 := time.Unix(1, 0)
+	t2 := time.Unix(2, 0)
+
+	previous := map[string]time.Time{
+		"same":      t1,
+		"modified":  t1,
+		"vanished":  t1,
+		"unwatched": t1,
+		"appeared":  {},
+	}
+
+	current := map[string]time.Time{
+		"same":     t1,
+		"modified": t2,
+		"vanished": {},
+		"appeared": t1,
+		"new":      t1,
+	}
+
+	assert.Equal(t, []string{"appeared", "modified", "new", "unwatched", "vanished"}, watchChanged(previous, current))
+	assert.Nil(t, watchChanged(current, current))
+}
+
+func Test_watchTimes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.enum.yaml")
+	missing := filepath.Join(dir, "b.enum.yaml")
+
+	assert.Nil(t, os.WriteFile(path, []byte("values: []\n"), FilePermissions))
+
+	previous := watchTimes([]string{path, missing})
+
+	assert.False(t, previous[path].IsZero())
+	assert.True(t, previous[missing].IsZero())
+
+	assert.Nil(t, os.Remove(path))
+	assert.Nil(t, os.WriteFile(missing, []byte("values: []\n"), FilePermissions))
+
+	assert.Equal(t, []string{path, missing}, watchChanged(previous, watchTimes([]string{path, missing})))
+}
+
+func Test_watchDeps(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"enumer.yaml": "defaults:\n    overwrite: true\n",
+		"color.enum.yaml": `include:
+    - shared/base.enum.yaml
+maps-to:
+    -   config: other.enum.yaml
+templates:
+    -   path: color.txt.tmpl
+    -   path: templates
+values-from:
+    path: colors.csv
+values:
+    - name: Red
+`,
+		"colors.csv":            "name\nGreen\n",
+		"shared/base.enum.yaml": "values:\n    - name: Blue\n",
+		"other.enum.yaml":       "values:\n    - name: Red\n",
+		"color.txt.tmpl":        "{{ .Type }}\n",
+		"templates/a.txt.tmpl":  "{{ .Type }}\n",
+		"templates/b.txt.tmpl":  "{{ .Type }}\n",
+	})
+
+	path := filepath.Join(dir, "color.enum.yaml")
+	files, err := watchDeps(argsData{}, path)
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		path,
+		filepath.Join(dir, "enumer.yaml"),
+		filepath.Join(dir, "shared", "base.enum.yaml"),
+		filepath.Join(dir, "other.enum.yaml"),
+		filepath.Join(dir, "colors.csv"),
+		filepath.Join(dir, "color.txt.tmpl"),
+		filepath.Join(dir, "templates"),
+		filepath.Join(dir, "templates", "a.txt.tmpl"),
+		filepath.Join(dir, "templates", "b.txt.tmpl"),
+	}, files)
+}
+
+func Test_watchDeps_recursive(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestFiles(t, dir, map[string]string{
+		"color.enum.yaml":        "extends: base/base.enum.yaml\nvalues:\n    - name: Red\n",
+		"base/enumer.yaml":       "defaults:\n    overwrite: true\n",
+		"base/base.enum.yaml":    "include:\n    - more.enum.yaml\nmaps-to:\n    -   config: ../color.enum.yaml\n",
+		"base/more.enum.yaml":    "values-from:\n    path: more.csv\n",
+		"base/more.csv":          "name\nGreen\n",
+		"broken.enum.yaml":       "extends: base/base.enum.yaml\nvalues: [\n",
+		"missing-base.enum.yaml": "extends: missing.enum.yaml\n",
+	})
+
+	files, err := watchDeps(argsData{}, filepath.Join(dir, "color.enum.yaml"))
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "color.enum.yaml"),
+		filepath.Join(dir, "base", "base.enum.yaml"),
+		filepath.Join(dir, "base", "enumer.yaml"),
+		filepath.Join(dir, "base", "more.enum.yaml"),
+		filepath.Join(dir, "base", "more.csv"),
+	}, files)
+
+	files, err = watchDeps(argsData{}, filepath.Join(dir, "broken.enum.yaml"))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "broken.enum.yaml")}, files)
+
+	_, err = watchDeps(argsData{}, filepath.Join(dir, "missing-base.enum.yaml"))
+
+	assert.NotNil(t, err)
+}
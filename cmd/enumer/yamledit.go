@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// yamlEdit is a change to the text of a yaml file which leaves the rest of
+// the file as it was written. Lines and columns are 1 based, as they are in
+// yaml.v3 nodes. An edit without a column inserts Text as whole lines before
+// Line, otherwise Length characters at Column are replaced with Text.
+type yamlEdit struct {
+	Line   int
+	Column int
+	Length int
+	Text   string
+}
+
+func applyYamlEdits(bs []byte, edits []yamlEdit) []byte {
+	lines := strings.Split(string(bs), "\n")
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Line != edits[j].Line {
+			return edits[i].Line > edits[j].Line
+		}
+
+		return edits[i].Column > edits[j].Column
+	})
+
+	for _, edit := range edits {
+		i := edit.Line - 1
+
+		if edit.Column == 0 {
+			inserted := strings.Split(edit.Text, "\n")
+			lines = append(lines[:i], append(inserted, lines[i:]...)...)
+			continue
+		}
+
+		line := []rune(lines[i])
+		start := edit.Column - 1
+		lines[i] = string(line[:start]) + edit.Text + string(line[start+edit.Length:])
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// yamlAddKey returns the edit which adds key to a block mapping in the yaml
+// text bs, with the same indentation as the keys already in it. The key has
+// either a scalar value or a block sequence of items.
+func yamlAddKey(bs []byte, mapping *yamlv3.Node, key string, value string, items ...string) (yamlEdit, error) {
+	if mapping == nil || mapping.Kind != yamlv3.MappingNode || mapping.Style&yamlv3.FlowStyle != 0 || len(mapping.Content) < 2 {
+		return yamlEdit{}, fmt.Errorf("can't add %v outside a block mapping", key)
+	}
+
+	column := mapping.Content[0].Column
+	indent := strings.Repeat(" ", column-1)
+	text := indent + key + ":"
+
+	if value != "" {
+		text += " " + value
+	}
+
+	for _, item := range items {
+		text += "\n" + indent + "    - " + item
+	}
+
+	// The second key always starts its own line, while the first shares the
+	// line of a sequence item's dash.
+	if len(mapping.Content) > 2 {
+		return yamlEdit{Line: mapping.Content[2].Line, Text: text}, nil
+	}
+
+	last := mapping.Content[1]
+
+	if last.Kind != yamlv3.ScalarNode || last.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) != 0 {
+		return yamlEdit{}, fmt.Errorf("can't add %v after a multi-line value", key)
+	}
+
+	// A scalar continues on the following lines indented past its key.
+	for _, line := range strings.Split(string(bs), "\n")[last.Line:] {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if len(line)-len(strings.TrimLeft(line, " ")) >= column {
+			return yamlEdit{}, fmt.Errorf("can't add %v after a multi-line value", key)
+		}
+
+		break
+	}
+
+	return yamlEdit{Line: last.Line + 1, Text: text}, nil
+}
+
+func yamlNodeValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,5 @@
+package enum_internal
+
+//go:generate enumer -config=./currency.enum.yaml
+//go:generate enumer -config=./crypto-currency.enum.yaml
+//go:generate enumer -config=./payment-currency.enum.yaml
@@ -0,0 +1,29 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/extends"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Extends_Values(t *testing.T) {
+	assert.Equal(t, []enum_internal.PaymentCurrency{
+		enum_internal.PaymentCurrencies.Usd,
+		enum_internal.PaymentCurrencies.Eur,
+		enum_internal.PaymentCurrencies.Gbp,
+		enum_internal.PaymentCurrencies.Btc,
+		enum_internal.PaymentCurrencies.Chf,
+	}, enum_internal.PaymentCurrencies.Values())
+}
+
+func Test_Extends_Override(t *testing.T) {
+	actual, err := enum_internal.PaymentCurrencies.Parse("euro")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.PaymentCurrencies.Eur, actual)
+}
+
+func Test_Extends_Remove(t *testing.T) {
+	_, err := enum_internal.PaymentCurrencies.Parse("jpy")
+	assert.ErrorIs(t, err, enum_internal.PaymentCurrencies.Err)
+}
@@ -0,0 +1,3 @@
+package enum_internal
+
+//go:generate enumer -config=./direction.enum.yaml
@@ -0,0 +1,55 @@
+package enum_internal_test
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/features"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Features_Included(t *testing.T) {
+	var value any = enum_internal.Directions.North
+
+	_, ok := value.(json.Marshaler)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, true, enum_internal.Directions.Is("north"))
+}
+
+func Test_Features_Excluded(t *testing.T) {
+	var value any = enum_internal.Directions.North
+
+	_, ok := value.(xml.Marshaler)
+	assert.Equal(t, false, ok)
+
+	_, ok = value.(driver.Valuer)
+	assert.Equal(t, false, ok)
+}
+
+func Test_Switch(t *testing.T) {
+	var actual string
+
+	enum_internal.Directions.South.Switch(
+		func() { actual = "north" },
+		func() { actual = "south" },
+		func(d enum_internal.Direction) { actual = "invalid" },
+	)
+
+	assert.Equal(t, "south", actual)
+}
+
+func Test_Match(t *testing.T) {
+	match := func(d enum_internal.Direction) int {
+		return enum_internal.MatchDirection(d,
+			func() int { return 1 },
+			func() int { return 2 },
+			func(enum_internal.Direction) int { return -1 },
+		)
+	}
+
+	assert.Equal(t, 1, match(enum_internal.Directions.North))
+	assert.Equal(t, 2, match(enum_internal.Directions.South))
+	assert.Equal(t, -1, match(enum_internal.Directions.Invalid))
+}
@@ -0,0 +1,38 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/groups"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Group_Values(t *testing.T) {
+	assert.Equal(t, []enum_internal.Unit{
+		enum_internal.Units.Meter,
+		enum_internal.Units.Kilometer,
+	}, enum_internal.Units.Metric())
+
+	assert.Equal(t, []enum_internal.Unit{
+		enum_internal.Units.NauticalMile,
+	}, enum_internal.Units.Nautical())
+}
+
+func Test_Group_Is(t *testing.T) {
+	assert.Equal(t, true, enum_internal.Units.Meter.IsMetric())
+	assert.Equal(t, false, enum_internal.Units.Meter.IsImperial())
+	assert.Equal(t, true, enum_internal.Units.NauticalMile.IsImperial())
+	assert.Equal(t, true, enum_internal.Units.NauticalMile.IsNautical())
+}
+
+func Test_Group_Parse(t *testing.T) {
+	actual, err := enum_internal.Units.ParseImperial("ft")
+
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Units.Foot, actual)
+
+	actual, err = enum_internal.Units.ParseImperial("km")
+
+	assert.ErrorIs(t, err, enum_internal.Units.Err)
+	assert.Equal(t, enum_internal.Units.Invalid, actual)
+}
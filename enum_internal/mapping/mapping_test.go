@@ -0,0 +1,31 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/mapping"
+	status "github.com/boundedinfinity/enumer/enum_internal/transitions"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToStatus(t *testing.T) {
+	assert.Equal(t, status.Statuses.Pending, enum_internal.JobStates.Queued.ToStatus())
+	assert.Equal(t, status.Statuses.Pending, enum_internal.JobStates.Scheduled.ToStatus())
+	assert.Equal(t, status.Statuses.Complete, enum_internal.JobStates.Done.ToStatus())
+	assert.Equal(t, status.Statuses.Failed, enum_internal.JobStates.Errored.ToStatus())
+	assert.Equal(t, status.Statuses.Invalid, enum_internal.JobStates.Invalid.ToStatus())
+}
+
+func Test_FromStatus(t *testing.T) {
+	actual, err := enum_internal.JobStates.FromStatus(status.Statuses.Pending)
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.JobStates.Queued, actual)
+
+	actual, err = enum_internal.JobStates.FromStatus(status.Statuses.Failed)
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.JobStates.Errored, actual)
+
+	actual, err = enum_internal.JobStates.FromStatus(status.Statuses.Cancelled)
+	assert.ErrorIs(t, err, enum_internal.JobStates.Err)
+	assert.Equal(t, enum_internal.JobStates.Invalid, actual)
+}
@@ -0,0 +1,4 @@
+package enum_internal
+
+//go:generate enumer -config=./severity.enum.yaml
+//go:generate enumer compat -config=./severity.enum.yaml
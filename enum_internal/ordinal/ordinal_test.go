@@ -0,0 +1,44 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/ordinal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Ordinal(t *testing.T) {
+	assert.Equal(t, 0, enum_internal.Severities.Low.Ordinal())
+	assert.Equal(t, 1, enum_internal.Severities.Medium.Ordinal())
+	assert.Equal(t, 2, enum_internal.Severities.High.Ordinal())
+	assert.Equal(t, 3, enum_internal.Severities.Critical.Ordinal())
+	assert.Equal(t, -1, enum_internal.Severities.Invalid.Ordinal())
+}
+
+func Test_Compare(t *testing.T) {
+	assert.Equal(t, -1, enum_internal.Severities.Low.Compare(enum_internal.Severities.High))
+	assert.Equal(t, 0, enum_internal.Severities.High.Compare(enum_internal.Severities.High))
+	assert.Equal(t, 1, enum_internal.Severities.Critical.Compare(enum_internal.Severities.Medium))
+	assert.Equal(t, true, enum_internal.Severities.Medium.Less(enum_internal.Severities.Critical))
+}
+
+func Test_Navigation(t *testing.T) {
+	assert.Equal(t, enum_internal.Severities.Medium, enum_internal.Severities.Low.Next())
+	assert.Equal(t, enum_internal.Severities.Invalid, enum_internal.Severities.Critical.Next())
+	assert.Equal(t, enum_internal.Severities.High, enum_internal.Severities.Critical.Prev())
+	assert.Equal(t, enum_internal.Severities.Invalid, enum_internal.Severities.Low.Prev())
+	assert.Equal(t, enum_internal.Severities.Low, enum_internal.Severities.Min())
+	assert.Equal(t, enum_internal.Severities.Critical, enum_internal.Severities.Max())
+}
+
+func Test_FromOrdinal(t *testing.T) {
+	actual, err := enum_internal.Severities.FromOrdinal(2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Severities.High, actual)
+
+	actual, err = enum_internal.Severities.FromOrdinal(4)
+
+	assert.ErrorIs(t, err, enum_internal.Severities.Err)
+	assert.Equal(t, enum_internal.Severities.Invalid, actual)
+}
@@ -0,0 +1,23 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/project"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Project_Translate(t *testing.T) {
+	actual, err := enum_internal.Languages.Parse("c++")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Languages.CPlusPlus, actual)
+
+	actual, err = enum_internal.Languages.Parse("CSharp")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Languages.CSharp, actual)
+}
+
+func Test_Project_Directory_Features(t *testing.T) {
+	assert.Equal(t, 0, enum_internal.Languages.CPlusPlus.Ordinal())
+	assert.Equal(t, 2, enum_internal.Languages.Go.Ordinal())
+}
@@ -0,0 +1,11 @@
+package pb
+
+// Status is a stand-in for the protoc-generated type of status.proto.
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_PENDING     Status = 1
+	Status_STATUS_RUNNING     Status = 2
+	Status_STATUS_COMPLETE    Status = 4
+)
@@ -0,0 +1,53 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/proto"
+	"github.com/boundedinfinity/enumer/enum_internal/proto/pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ToProto(t *testing.T) {
+	assert.Equal(t, pb.Status_STATUS_PENDING, enum_internal.Statuses.Pending.ToProto())
+	assert.Equal(t, pb.Status_STATUS_RUNNING, enum_internal.Statuses.Running.ToProto())
+	assert.Equal(t, pb.Status_STATUS_COMPLETE, enum_internal.Statuses.Complete.ToProto())
+	assert.Equal(t, pb.Status_STATUS_UNSPECIFIED, enum_internal.Statuses.Invalid.ToProto())
+}
+
+func Test_FromProto(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    pb.Status
+		expected enum_internal.Status
+		err      error
+	}{
+		{
+			name:     "case 1",
+			input:    pb.Status_STATUS_RUNNING,
+			expected: enum_internal.Statuses.Running,
+			err:      nil,
+		},
+		{
+			name:     "case 2",
+			input:    pb.Status_STATUS_UNSPECIFIED,
+			expected: enum_internal.Statuses.Invalid,
+			err:      enum_internal.Statuses.Err,
+		},
+		{
+			name:     "case 3",
+			input:    pb.Status(3),
+			expected: enum_internal.Statuses.Invalid,
+			err:      enum_internal.Statuses.Err,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			actual, err := enum_internal.Statuses.FromProto(tc.input)
+
+			assert.ErrorIs(tt, err, tc.err)
+			assert.Equal(tt, tc.expected, actual)
+		})
+	}
+}
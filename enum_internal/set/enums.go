@@ -0,0 +1,3 @@
+package enum_internal
+
+//go:generate enumer -config=./permission.enum.yaml
@@ -0,0 +1,50 @@
+package enum_internal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/set"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Map_Get_Set(t *testing.T) {
+	m := enum_internal.NewPermissionMap("r", "w", "x")
+
+	assert.Equal(t, "w", m.Get(enum_internal.Permissions.Write))
+	assert.Equal(t, "", m.Get(enum_internal.Permissions.Invalid))
+
+	m.Set(enum_internal.Permissions.Write, "W")
+
+	assert.Equal(t, "W", m.Get(enum_internal.Permissions.Write))
+}
+
+func Test_Map_Range(t *testing.T) {
+	m := enum_internal.NewPermissionMap(4, 2, 1)
+	var keys []enum_internal.Permission
+	var sum int
+
+	m.Range(func(key enum_internal.Permission, value int) bool {
+		keys = append(keys, key)
+		sum += value
+		return key != enum_internal.Permissions.Write
+	})
+
+	assert.Equal(t, []enum_internal.Permission{enum_internal.Permissions.Read, enum_internal.Permissions.Write}, keys)
+	assert.Equal(t, 6, sum)
+}
+
+func Test_Map_Json(t *testing.T) {
+	input := enum_internal.NewPermissionMap(4, 2, 1)
+	bs, err := json.Marshal(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"read":4,"write":2,"execute":1}`, string(bs))
+
+	var actual enum_internal.PermissionMap[int]
+
+	assert.Nil(t, json.Unmarshal(bs, &actual))
+	assert.Equal(t, input, actual)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"read":4}`), &actual), enum_internal.Permissions.Err)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"turd":4}`), &actual), enum_internal.Permissions.Err)
+}
@@ -0,0 +1,81 @@
+package enum_internal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/set"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func Test_Set_Add_Remove(t *testing.T) {
+	s := enum_internal.NewPermissionSet(enum_internal.Permissions.Read)
+	s.Add(enum_internal.Permissions.Execute, enum_internal.Permissions.Invalid)
+
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, true, s.Contains(enum_internal.Permissions.Execute))
+	assert.Equal(t, false, s.Contains(enum_internal.Permissions.Write))
+
+	s.Remove(enum_internal.Permissions.Execute)
+
+	assert.Equal(t, []enum_internal.Permission{enum_internal.Permissions.Read}, s.Values())
+}
+
+func Test_Set_Algebra(t *testing.T) {
+	a := enum_internal.NewPermissionSet(enum_internal.Permissions.Read, enum_internal.Permissions.Write)
+	b := enum_internal.NewPermissionSet(enum_internal.Permissions.Write, enum_internal.Permissions.Execute)
+
+	assert.Equal(t, 3, a.Union(b).Len())
+	assert.Equal(t, enum_internal.NewPermissionSet(enum_internal.Permissions.Write), a.Intersect(b))
+	assert.Equal(t, enum_internal.NewPermissionSet(enum_internal.Permissions.Read), a.Difference(b))
+	assert.Equal(t, enum_internal.NewPermissionSet(enum_internal.Permissions.Execute), a.Complement())
+	assert.Equal(t, true, a.Intersect(a.Complement()).IsEmpty())
+}
+
+func Test_Set_Json(t *testing.T) {
+	input := enum_internal.NewPermissionSet(enum_internal.Permissions.Execute, enum_internal.Permissions.Read)
+	bs, err := json.Marshal(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `["read","execute"]`, string(bs))
+
+	var actual enum_internal.PermissionSet
+
+	assert.Nil(t, json.Unmarshal(bs, &actual))
+	assert.Equal(t, input, actual)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`["read","turd"]`), &actual), enum_internal.Permissions.Err)
+
+	bs, err = json.Marshal(enum_internal.PermissionSet{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(bs))
+}
+
+func Test_Set_Yaml(t *testing.T) {
+	input := enum_internal.NewPermissionSet(enum_internal.Permissions.Write)
+	bs, err := yaml.Marshal(input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "- write\n", string(bs))
+
+	var actual enum_internal.PermissionSet
+
+	assert.Nil(t, yaml.Unmarshal(bs, &actual))
+	assert.Equal(t, input, actual)
+}
+
+func Test_Set_Sql(t *testing.T) {
+	input := enum_internal.NewPermissionSet(enum_internal.Permissions.Read, enum_internal.Permissions.Write)
+	value, err := input.Value()
+
+	assert.Nil(t, err)
+	assert.Equal(t, `["read","write"]`, value)
+
+	var actual enum_internal.PermissionSet
+
+	assert.Nil(t, actual.Scan(value))
+	assert.Equal(t, input, actual)
+	assert.Nil(t, actual.Scan(nil))
+	assert.Equal(t, true, actual.IsEmpty())
+}
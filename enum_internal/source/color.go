@@ -0,0 +1,18 @@
+package enum_internal
+
+//go:generate enumer -source=./color.go
+
+// A primary color
+//
+//enumer:enum
+type Color string
+
+const (
+	// The color of fire
+	ColorRed   Color = "red" //enumer:parse-from r rouge
+	ColorGreen Color = "green"
+	// The color of the sky
+	//
+	//enumer:parse-from b
+	ColorBlue Color = "blue"
+)
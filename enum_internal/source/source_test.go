@@ -0,0 +1,39 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/source"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Source_Values(t *testing.T) {
+	assert.Equal(t, []enum_internal.Color{
+		enum_internal.ColorRed,
+		enum_internal.ColorGreen,
+		enum_internal.ColorBlue,
+	}, enum_internal.Colors.Values())
+}
+
+func Test_Source_Parse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected enum_internal.Color
+		err      error
+	}{
+		{input: "red", expected: enum_internal.ColorRed},
+		{input: "rouge", expected: enum_internal.ColorRed},
+		{input: "r", expected: enum_internal.ColorRed},
+		{input: "b", expected: enum_internal.ColorBlue},
+		{input: "Green", expected: enum_internal.ColorGreen},
+		{input: "purple", expected: enum_internal.Colors.Invalid, err: enum_internal.Colors.Err},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(tt *testing.T) {
+			actual, err := enum_internal.Colors.Parse(tc.input)
+			assert.ErrorIs(tt, err, tc.err)
+			assert.Equal(tt, tc.expected, actual)
+		})
+	}
+}
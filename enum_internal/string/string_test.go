@@ -1,6 +1,7 @@
 package enum_internal_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 
@@ -97,3 +98,57 @@ func Test_Yaml_Unmarshal(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func Test_Schema(t *testing.T) {
+	schema := enum_internal.MyStrings.Schema()
+
+	assert.Equal(t, "string", schema["type"])
+	assert.Equal(t, "MyString", schema["title"])
+	assert.Equal(t, "This is a test description With more than one line", schema["description"])
+	assert.Equal(t, []any{"my-string-1", "my-string-2", "my-string-3"}, schema["enum"])
+	assert.Equal(t, []any{"MyString1", "MyString2", "MyString3"}, schema["x-enum-varnames"])
+}
+
+func Test_GraphQL_Marshal(t *testing.T) {
+	var buf bytes.Buffer
+	enum_internal.MyStrings.MyString1.MarshalGQL(&buf)
+
+	assert.Equal(t, `"MY_STRING_1"`, buf.String())
+}
+
+func Test_GraphQL_Unmarshal(t *testing.T) {
+	var actual enum_internal.MyString
+
+	err := actual.UnmarshalGQL("MY_STRING_2")
+
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.MyStrings.MyString2, actual)
+
+	err = actual.UnmarshalGQL(42)
+
+	assert.ErrorIs(t, err, enum_internal.MyStrings.Err)
+}
+
+func Test_Parse_Allocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = enum_internal.MyStrings.Parse("my-string-3")
+	})
+
+	assert.Equal(t, float64(0), allocs)
+}
+
+func Benchmark_Parse(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = enum_internal.MyStrings.Parse("my-string-3")
+	}
+}
+
+func Benchmark_ParseFrom(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = enum_internal.MyStrings.ParseFrom("my-string-3", enum_internal.MyStrings.Values()...)
+	}
+}
@@ -0,0 +1,18 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Template_Method(t *testing.T) {
+	assert.Equal(t, "Light Red", enum_internal.Colors.LightRed.Title())
+	assert.Equal(t, "Dark Blue", enum_internal.Colors.DarkBlue.Title())
+}
+
+func Test_Template_Function(t *testing.T) {
+	assert.Equal(t, true, enum_internal.IsColorValue("light-red"))
+	assert.Equal(t, false, enum_internal.IsColorValue("green"))
+}
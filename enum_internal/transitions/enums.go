@@ -0,0 +1,4 @@
+package enum_internal
+
+//go:generate enumer -config=./status.enum.yaml
+//go:generate enumer dot -config=./status.enum.yaml -output=./status.enum.dot
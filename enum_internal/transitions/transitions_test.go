@@ -0,0 +1,49 @@
+package enum_internal_test
+
+import (
+	"os"
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/transitions"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Transitions(t *testing.T) {
+	assert.Equal(t, []enum_internal.Status{
+		enum_internal.Statuses.Running,
+		enum_internal.Statuses.Cancelled,
+	}, enum_internal.Statuses.Pending.Transitions())
+
+	assert.Nil(t, enum_internal.Statuses.Complete.Transitions())
+}
+
+func Test_CanTransitionTo(t *testing.T) {
+	assert.Equal(t, true, enum_internal.Statuses.Pending.CanTransitionTo(enum_internal.Statuses.Running))
+	assert.Equal(t, false, enum_internal.Statuses.Pending.CanTransitionTo(enum_internal.Statuses.Complete))
+	assert.Equal(t, false, enum_internal.Statuses.Complete.CanTransitionTo(enum_internal.Statuses.Pending))
+}
+
+func Test_Initial_Terminal(t *testing.T) {
+	assert.Equal(t, true, enum_internal.Statuses.Pending.IsInitial())
+	assert.Equal(t, false, enum_internal.Statuses.Running.IsInitial())
+	assert.Equal(t, true, enum_internal.Statuses.Failed.IsTerminal())
+	assert.Equal(t, false, enum_internal.Statuses.Running.IsTerminal())
+}
+
+func Test_Dot(t *testing.T) {
+	actual, err := os.ReadFile("status.enum.dot")
+
+	assert.Nil(t, err)
+	assert.Equal(t, `digraph "Status" {
+    "pending";
+    "running";
+    "complete";
+    "failed";
+    "cancelled";
+    "pending" -> "running";
+    "pending" -> "cancelled";
+    "running" -> "complete";
+    "running" -> "failed";
+}
+`, string(actual))
+}
@@ -0,0 +1,4 @@
+package enum_internal
+
+//go:generate enumer -config=./country.enum.yaml
+//go:generate enumer -config=./mime-type.enum.yaml
@@ -0,0 +1,36 @@
+package enum_internal_test
+
+import (
+	"testing"
+
+	enum_internal "github.com/boundedinfinity/enumer/enum_internal/valuesfrom"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValuesFrom_Csv(t *testing.T) {
+	assert.Equal(t, []enum_internal.Country{
+		enum_internal.Countries.UnitedStates,
+		enum_internal.Countries.Germany,
+		enum_internal.Countries.Japan,
+	}, enum_internal.Countries.Values())
+
+	actual, err := enum_internal.Countries.Parse("DEU")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Countries.Germany, actual)
+
+	actual, err = enum_internal.Countries.Parse("nippon")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.Countries.Japan, actual)
+
+	assert.Equal(t, "276", enum_internal.Countries.Germany.Numeric())
+	assert.Equal(t, "392", enum_internal.Countries.Japan.Numeric())
+	assert.Equal(t, "jp", enum_internal.Countries.Japan.String())
+}
+
+func Test_ValuesFrom_Json(t *testing.T) {
+	actual, err := enum_internal.MimeTypes.Parse("htm")
+	assert.Nil(t, err)
+	assert.Equal(t, enum_internal.MimeTypes.Html, actual)
+	assert.Equal(t, "text/html", actual.String())
+	assert.Equal(t, ".html", actual.Extension())
+}
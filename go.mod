@@ -1,8 +1,8 @@
 module github.com/boundedinfinity/enumer
 
-go 1.21
+go 1.22
 
-toolchain go1.21.4
+toolchain go1.22.0
 
 require (
 	github.com/boundedinfinity/asciibox v0.0.0-20210528224626-4bc42ed218ca
@@ -11,6 +11,7 @@ require (
 	github.com/gertd/go-pluralize v0.2.1
 	github.com/stretchr/testify v1.9.0
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -20,7 +21,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/exp v0.0.0-20231006140011-7918f672742d // indirect
 	golang.org/x/text v0.3.7 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
-
-
@@ -1,32 +1,95 @@
 package enumer
 
 type EnumData struct {
-	Type        string            `json:"type,omitempty" yaml:"type,omitempty"`
-	Struct      string            `json:"struct,omitempty" yaml:"struct,omitempty"`
-	Package     string            `json:"package,omitempty" yaml:"package,omitempty"`
-	InputPath   string            `json:"input-path,omitempty" yaml:"input-path,omitempty"`
-	OutputPath  string            `json:"output-path,omitempty" yaml:"output-path,omitempty"`
-	Desc        string            `json:"desc" yaml,omitempty:"desc,omitempty"`
-	Header      string            `json:"header,omitempty" yaml:"header,omitempty"`
-	HeaderFrom  string            `json:"header-from,omitempty" yaml:"header-from,omitempty"`
-	HeaderLines []string          `json:"header-lines,omitempty" yaml:"header-lines,omitempty"`
-	SkipFormat  bool              `json:"skip-format,omitempty" yaml:"skip-format,omitempty"`
-	Debug       bool              `json:"debug,omitempty" yaml:"debug,omitempty"`
-	Overwrite   bool              `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
-	Serialize   EnumSerialize     `json:"serialize,omitempty" yaml:"serialize,omitempty"`
-	Values      []EnumValue       `json:"values,omitempty" yaml:"values,omitempty"`
-	Translate   map[string]string `json:"translate,omitempty" yaml:"translate,omitempty"`
+	Type        string              `json:"type,omitempty" yaml:"type,omitempty" desc:"The Go type name, defaults to the file name in pascal case."`
+	Struct      string              `json:"struct,omitempty" yaml:"struct,omitempty" desc:"The companion struct name, defaults to the plural of the type."`
+	Package     string              `json:"package,omitempty" yaml:"package,omitempty" desc:"The Go package name, defaults to the output directory name."`
+	InputPath   string              `json:"input-path,omitempty" yaml:"input-path,omitempty" schema:"-" desc:"The config path, set by the generator."`
+	OutputPath  string              `json:"output-path,omitempty" yaml:"output-path,omitempty" desc:"The generated Go file, defaults to the config path with a .go extension."`
+	Desc        string              `json:"desc" yaml:"desc,omitempty" desc:"The description of the type."`
+	Header      string              `json:"header,omitempty" yaml:"header,omitempty" desc:"The header comment of the generated file."`
+	HeaderFrom  string              `json:"header-from,omitempty" yaml:"header-from,omitempty" desc:"A file containing the header comment of the generated file."`
+	HeaderLines []string            `json:"header-lines,omitempty" yaml:"header-lines,omitempty" schema:"-" desc:"The lines of the header comment of the generated file."`
+	SkipType    bool                `json:"skip-type,omitempty" yaml:"skip-type,omitempty" desc:"Skip declaring the type, when it is declared elsewhere in the package."`
+	SkipFormat  bool                `json:"skip-format,omitempty" yaml:"skip-format,omitempty" desc:"Skip source formatting."`
+	Debug       bool                `json:"debug,omitempty" yaml:"debug,omitempty" schema:"-" desc:"Enable debugging."`
+	Overwrite   bool                `json:"overwrite,omitempty" yaml:"overwrite,omitempty" desc:"Overwrite existing generated files."`
+	Features    []string            `json:"features,omitempty" yaml:"features,omitempty" enum:"features" desc:"The features generated, defaults to the default features."`
+	Exclude     []string            `json:"exclude,omitempty" yaml:"exclude,omitempty" enum:"features" desc:"The features not generated."`
+	Serialize   EnumSerialize       `json:"serialize,omitempty" yaml:"serialize,omitempty" desc:"How names are converted to serialized values."`
+	Values      []EnumValue         `json:"values,omitempty" yaml:"values,omitempty" desc:"The enum values."`
+	Translate   map[string]string   `json:"translate,omitempty" yaml:"translate,omitempty" desc:"Replacements applied to value names before they become Go identifiers."`
+	Proto       *EnumProto          `json:"proto,omitempty" yaml:"proto,omitempty" desc:"Generate a protobuf enum and conversions."`
+	Schema      *EnumSchema         `json:"schema,omitempty" yaml:"schema,omitempty" desc:"Generate a JSON schema for the enum."`
+	GraphQL     *EnumGraphQL        `json:"graphql,omitempty" yaml:"graphql,omitempty" desc:"Generate a GraphQL enum and marshalers."`
+	Templates   []EnumTemplate      `json:"templates,omitempty" yaml:"templates,omitempty" desc:"Go templates rendered with the enum."`
+	Transitions map[string][]string `json:"transitions,omitempty" yaml:"transitions,omitempty" desc:"The values each value can transition to."`
+	MapsTo      []EnumMapping       `json:"maps-to,omitempty" yaml:"maps-to,omitempty" desc:"Other enums this enum converts to and from."`
+	Extends     string              `json:"extends,omitempty" yaml:"extends,omitempty" desc:"A config whose values and unset settings this enum inherits."`
+	Include     []string            `json:"include,omitempty" yaml:"include,omitempty" desc:"Configs whose values are added to the inherited values."`
+	Remove      []string            `json:"remove,omitempty" yaml:"remove,omitempty" desc:"Inherited values removed before this enum's own values are added."`
+	ValuesFrom  *EnumValuesFrom     `json:"values-from,omitempty" yaml:"values-from,omitempty" desc:"A CSV, JSON or YAML file the values are loaded from."`
+}
+
+type EnumProject struct {
+	Defaults    map[string]any            `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Translate   map[string]string         `json:"translate,omitempty" yaml:"translate,omitempty"`
+	Directories map[string]map[string]any `json:"directories,omitempty" yaml:"directories,omitempty"`
+}
+
+type EnumValuesFrom struct {
+	Path       string            `json:"path,omitempty" yaml:"path,omitempty" required:"true" desc:"The data file, relative to the config."`
+	Format     string            `json:"format,omitempty" yaml:"format,omitempty" enum:"csv,json,yaml" desc:"The data file format, defaults to the file extension."`
+	Columns    map[string]string `json:"columns,omitempty" yaml:"columns,omitempty" desc:"The column holding each value field, defaults to a column of the same name."`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty" desc:"The column holding each attribute."`
+	Separator  string            `json:"separator,omitempty" yaml:"separator,omitempty" desc:"The separator of parse-from aliases in a single column, defaults to |."`
+}
+
+type EnumMapping struct {
+	Config   string            `json:"config,omitempty" yaml:"config,omitempty" required:"true" desc:"The config of the enum mapped to, relative to this config."`
+	Import   string            `json:"import,omitempty" yaml:"import,omitempty" desc:"The import path of the enum mapped to, defaults to the path in the module."`
+	Values   map[string]string `json:"values,omitempty" yaml:"values,omitempty" desc:"The value each value maps to."`
+	Fallback string            `json:"fallback,omitempty" yaml:"fallback,omitempty" desc:"The value unmapped values map to."`
+}
+
+type EnumTemplate struct {
+	Path       string   `json:"path,omitempty" yaml:"path,omitempty" required:"true" desc:"A template file or directory of .tmpl files, relative to the config."`
+	OutputPath string   `json:"output-path,omitempty" yaml:"output-path,omitempty" desc:"The rendered file, defaults to the template path without .tmpl."`
+	Imports    []string `json:"imports,omitempty" yaml:"imports,omitempty" desc:"Imports added to rendered Go code."`
+}
+
+type EnumGraphQL struct {
+	Name       string `json:"name,omitempty" yaml:"name,omitempty" desc:"The GraphQL enum name, defaults to the type."`
+	OutputPath string `json:"output-path,omitempty" yaml:"output-path,omitempty" desc:"The generated GraphQL schema file."`
+}
+
+type EnumSchema struct {
+	Format     string `json:"format,omitempty" yaml:"format,omitempty" enum:"json-schema,openapi" desc:"The schema format."`
+	OutputPath string `json:"output-path,omitempty" yaml:"output-path,omitempty" desc:"The generated schema file."`
+}
+
+type EnumProto struct {
+	Name       string `json:"name,omitempty" yaml:"name,omitempty" desc:"The protobuf enum name, defaults to the type."`
+	Package    string `json:"package,omitempty" yaml:"package,omitempty" desc:"The protobuf package, defaults to the Go package."`
+	GoPackage  string `json:"go-package,omitempty" yaml:"go-package,omitempty" desc:"The go_package option of the generated .proto file."`
+	GoType     string `json:"go-type,omitempty" yaml:"go-type,omitempty" desc:"The protoc generated Go type converted to and from, as import/path.Type."`
+	OutputPath string `json:"output-path,omitempty" yaml:"output-path,omitempty" desc:"The generated .proto file."`
+	Reserved   []int  `json:"reserved,omitempty" yaml:"reserved,omitempty" desc:"Protobuf numbers which are never assigned."`
 }
 
 type EnumSerialize struct {
-	Type  string `json:"type,omitempty" yaml:"type,omitempty"`
-	Value string `json:"value,omitempty" yaml:"value,omitempty"`
+	Type  string `json:"type,omitempty" yaml:"type,omitempty" enum:"converters" desc:"The converter applied to names."`
+	Value string `json:"value,omitempty" yaml:"value,omitempty" enum:"converters" desc:"The converter applied to names to build serialized values."`
 }
 
 type EnumValue struct {
-	Name       string            `json:"name,omitempty" yaml:"name,omitempty"`
-	Desc       string            `json:"desc,omitempty" yaml:"desc,omitempty"`
-	Serialized string            `json:"serialized,omitempty" yaml:"serialized,omitempty"`
-	ParseFrom  []string          `json:"parse-from,omitempty" yaml:"parse-from,omitempty"`
-	Translate  map[string]string `json:"translate,omitempty" yaml:"translate,omitempty"`
+	Name        string            `json:"name,omitempty" yaml:"name,omitempty" required:"any" desc:"The value name, used for the Go identifier."`
+	Desc        string            `json:"desc,omitempty" yaml:"desc,omitempty" desc:"The description of the value."`
+	Serialized  string            `json:"serialized,omitempty" yaml:"serialized,omitempty" required:"any" desc:"The serialized value, defaults to the name converted to a JSON identifier."`
+	ParseFrom   []string          `json:"parse-from,omitempty" yaml:"parse-from,omitempty" desc:"Other strings which parse to the value."`
+	Translate   map[string]string `json:"translate,omitempty" yaml:"translate,omitempty" desc:"Replacements applied to the name before it becomes a Go identifier."`
+	ProtoNumber int               `json:"proto-number,omitempty" yaml:"proto-number,omitempty" desc:"The protobuf number, assigned when missing."`
+	Rank        *int              `json:"rank,omitempty" yaml:"rank,omitempty" desc:"The ordinal rank, defaults to the position in the values list."`
+	Groups      []string          `json:"groups,omitempty" yaml:"groups,omitempty" desc:"The groups the value belongs to."`
+	Attributes  map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty" desc:"Extra attributes, each generating an accessor method."`
 }